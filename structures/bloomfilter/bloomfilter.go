@@ -1 +1,182 @@
+//
+// Package bloomfilter implements Bloom filter probabilistic data structure.
+//
+// Filter is sized from expected number of items and desired false-positive
+// probability. Instead of computing k independent hashes, it derives all of
+// them from two base hashes (Kirsch-Mitzenmacher double hashing):
+//
+//	g(i) = h1 + i*h2 mod m
+//
+// https://en.wikipedia.org/wiki/Bloom_filter
+// https://www.eecs.harvard.edu/~michaelm/postscripts/rsa2008.pdf
+//
 package bloomfilter
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// defaultSeed is mixed into every hash computation
+const defaultSeed uint64 = 0x9e3779b97f4a7c15
+
+// BloomFilter is a classic Bloom filter backed by bit array
+type BloomFilter struct {
+	m    uint64 // number of bits
+	k    uint64 // number of hash functions
+	seed uint64
+	bits []uint64
+}
+
+// NewBloomFilter creates filter which holds approximately n items
+// with false-positive probability not exceeding p
+func NewBloomFilter(n int, p float64) (*BloomFilter, error) {
+	return NewBloomFilterWithSeed(n, p, defaultSeed)
+}
+
+// NewBloomFilterWithSeed creates filter with custom hash seed
+// Filters must share the seed to produce same bits for same items
+func NewBloomFilterWithSeed(n int, p float64, seed uint64) (*BloomFilter, error) {
+	if n <= 0 {
+		return nil, errors.New("expected number of items must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		return nil, errors.New("false-positive probability must be in range (0, 1)")
+	}
+	m, k := OptimalParameters(n, p)
+	return newBloomFilter(m, k, seed), nil
+}
+
+// newBloomFilter with exact number of bits and hash functions
+func newBloomFilter(m, k, seed uint64) *BloomFilter {
+	return &BloomFilter{
+		m:    m,
+		k:    k,
+		seed: seed,
+		bits: make([]uint64, (m+63)/64),
+	}
+}
+
+// OptimalParameters returns number of bits m and number of hash functions k
+// for filter holding n items with false-positive probability p
+//
+//	m = -n*ln(p) / ln(2)^2
+//	k = m/n * ln(2)
+func OptimalParameters(n int, p float64) (m, k uint64) {
+	m = uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m == 0 {
+		m = 1
+	}
+	k = uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return m, k
+}
+
+// BitSize returns number of bits in filter
+func (f *BloomFilter) BitSize() uint64 {
+	return f.m
+}
+
+// HashCount returns number of hash functions used by filter
+func (f *BloomFilter) HashCount() uint64 {
+	return f.k
+}
+
+// Add data to filter
+func (f *BloomFilter) Add(data []byte) {
+	f.add(baseHashes(f.seed, data))
+}
+
+// AddString adds string to filter
+func (f *BloomFilter) AddString(s string) {
+	f.add(baseHashes(f.seed, s))
+}
+
+// Test returns true if data is possibly in filter
+// False means data is definitely not in filter
+func (f *BloomFilter) Test(data []byte) bool {
+	return f.test(baseHashes(f.seed, data))
+}
+
+// TestString returns true if string is possibly in filter
+func (f *BloomFilter) TestString(s string) bool {
+	return f.test(baseHashes(f.seed, s))
+}
+
+// EstimatedFPR returns current false-positive probability
+// estimated from fraction of bits set: (X/m)^k
+func (f *BloomFilter) EstimatedFPR() float64 {
+	return math.Pow(float64(f.popCount())/float64(f.m), float64(f.k))
+}
+
+// ApproximateCount of distinct items added to filter (Swamidass & Baldi)
+//
+//	n* = -m/k * ln(1 - X/m)
+func (f *BloomFilter) ApproximateCount() uint64 {
+	x := f.popCount()
+	if x == f.m {
+		// every bit is set, estimation is not possible
+		return math.MaxUint64
+	}
+	n := -float64(f.m) / float64(f.k) * math.Log(1-float64(x)/float64(f.m))
+	return uint64(math.Round(n))
+}
+
+// Reset clears all bits
+func (f *BloomFilter) Reset() {
+	clear(f.bits)
+}
+
+func (f *BloomFilter) add(h1, h2 uint64) {
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+func (f *BloomFilter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// popCount returns number of set bits
+func (f *BloomFilter) popCount() uint64 {
+	var cnt int
+	for _, w := range f.bits {
+		cnt += bits.OnesCount64(w)
+	}
+	return uint64(cnt)
+}
+
+// baseHashes computes two base hashes for double hashing
+// h1 is seeded FNV-1a, h2 is h1 passed through splitmix64 finalizer,
+// h2 is forced to be odd so it never degenerates into zero step
+func baseHashes[T ~string | ~[]byte](seed uint64, data T) (uint64, uint64) {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64) ^ seed
+	for i := 0; i < len(data); i++ {
+		h ^= uint64(data[i])
+		h *= prime64
+	}
+	h1 := mix64(h)
+	h2 := mix64(h1) | 1
+	return h1, h2
+}
+
+// mix64 is splitmix64 finalizer
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
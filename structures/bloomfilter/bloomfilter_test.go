@@ -1 +1,105 @@
 package bloomfilter
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	_, err := NewBloomFilter(0, 0.01)
+	assert.Error(t, err)
+	_, err = NewBloomFilter(100, 0)
+	assert.Error(t, err)
+	_, err = NewBloomFilter(100, 1)
+	assert.Error(t, err)
+
+	f, err := NewBloomFilter(1000, 0.01)
+	assert.NoError(t, err)
+	// m = -1000*ln(0.01)/ln(2)^2 ~ 9586, k = 9586/1000*ln(2) ~ 7
+	assert.Equal(t, uint64(9586), f.BitSize())
+	assert.Equal(t, uint64(7), f.HashCount())
+}
+
+func TestBloomFilter_AddTest(t *testing.T) {
+	f, err := NewBloomFilter(1000, 0.01)
+	assert.NoError(t, err)
+
+	assert.False(t, f.Test([]byte("foo")))
+	assert.False(t, f.TestString("foo"))
+
+	f.Add([]byte("foo"))
+	f.AddString("bar")
+
+	assert.True(t, f.Test([]byte("foo")))
+	assert.True(t, f.TestString("foo"))
+	assert.True(t, f.Test([]byte("bar")))
+	assert.True(t, f.TestString("bar"))
+	assert.False(t, f.TestString("buzz"))
+
+	f.Reset()
+	assert.False(t, f.TestString("foo"))
+	assert.Equal(t, float64(0), f.EstimatedFPR())
+}
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	const (
+		n = 10000
+		p = 0.01
+	)
+	f, err := NewBloomFilter(n, p)
+	assert.NoError(t, err)
+
+	for i := 0; i < n; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	// no false negatives
+	for i := 0; i < n; i++ {
+		assert.True(t, f.TestString(strconv.Itoa(i)))
+	}
+
+	var fp int
+	for i := n; i < 11*n; i++ {
+		if f.TestString(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	observed := float64(fp) / float64(10*n)
+	assert.InDelta(t, p, observed, p/2)
+	assert.InDelta(t, p, f.EstimatedFPR(), p/2)
+}
+
+func TestBloomFilter_ApproximateCount(t *testing.T) {
+	f, err := NewBloomFilter(5000, 0.001)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), f.ApproximateCount())
+
+	for i := 0; i < 3000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	// re-adding same items does not change estimation
+	for i := 0; i < 3000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	assert.InDelta(t, 3000, f.ApproximateCount(), 3000*0.02)
+}
+
+func BenchmarkBloomFilter_Add(b *testing.B) {
+	f, _ := NewBloomFilter(b.N+1, 0.01)
+	data := []byte("benchmark")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Add(data)
+	}
+}
+
+func BenchmarkBloomFilter_Test(b *testing.B) {
+	f, _ := NewBloomFilter(1000, 0.01)
+	data := []byte("benchmark")
+	f.Add(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Test(data)
+	}
+}
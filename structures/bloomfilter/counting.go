@@ -0,0 +1,165 @@
+package bloomfilter
+
+import (
+	"errors"
+	"math"
+)
+
+// CounterMax is maximum value of 4-bit counter
+const CounterMax = 1<<4 - 1
+
+// ErrNotFound is returned when removing item which is not in filter
+var ErrNotFound = errors.New("item is not in filter")
+
+// CountingBloomFilter is bloom filter with 4-bit counters instead of bits,
+// which makes removal of items possible
+//
+// Counter which reaches CounterMax becomes saturated: it is never incremented
+// nor decremented again. Removal of items sharing saturated counter can not
+// produce false negatives, but such counters are never released either.
+//
+// https://en.wikipedia.org/wiki/Counting_Bloom_filter
+type CountingBloomFilter struct {
+	m         uint64 // number of counters
+	k         uint64 // number of hash functions
+	seed      uint64
+	count     uint64 // items currently in filter
+	saturated uint64 // number of saturated counters
+	counters  []byte // two counters per byte, low nibble is even counter
+}
+
+// NewCountingBloomFilter creates filter which holds approximately n items
+// with false-positive probability not exceeding p
+func NewCountingBloomFilter(n int, p float64) (*CountingBloomFilter, error) {
+	if n <= 0 {
+		return nil, errors.New("expected number of items must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		return nil, errors.New("false-positive probability must be in range (0, 1)")
+	}
+	m, k := OptimalParameters(n, p)
+	return &CountingBloomFilter{
+		m:        m,
+		k:        k,
+		seed:     defaultSeed,
+		counters: make([]byte, (m+1)/2),
+	}, nil
+}
+
+// Count returns number of items currently in filter
+func (f *CountingBloomFilter) Count() uint64 {
+	return f.count
+}
+
+// Saturated returns number of counters which reached CounterMax
+func (f *CountingBloomFilter) Saturated() uint64 {
+	return f.saturated
+}
+
+// Add data to filter
+// Returns false if any of item counters overflowed and became saturated
+func (f *CountingBloomFilter) Add(data []byte) bool {
+	return f.add(baseHashes(f.seed, data))
+}
+
+// AddString adds string to filter
+// Returns false if any of item counters overflowed and became saturated
+func (f *CountingBloomFilter) AddString(s string) bool {
+	return f.add(baseHashes(f.seed, s))
+}
+
+// Test returns true if data is possibly in filter
+func (f *CountingBloomFilter) Test(data []byte) bool {
+	return f.test(baseHashes(f.seed, data))
+}
+
+// TestString returns true if string is possibly in filter
+func (f *CountingBloomFilter) TestString(s string) bool {
+	return f.test(baseHashes(f.seed, s))
+}
+
+// Remove data from filter
+// Returns ErrNotFound if data is definitely not in filter,
+// in this case filter stays unchanged
+// Removing item which was never added may remove other items
+func (f *CountingBloomFilter) Remove(data []byte) error {
+	return f.remove(baseHashes(f.seed, data))
+}
+
+// RemoveString removes string from filter
+func (f *CountingBloomFilter) RemoveString(s string) error {
+	return f.remove(baseHashes(f.seed, s))
+}
+
+// EstimatedFPR returns current false-positive probability
+// estimated from fraction of non-zero counters
+func (f *CountingBloomFilter) EstimatedFPR() float64 {
+	var nonZero uint64
+	for pos := uint64(0); pos < f.m; pos++ {
+		if f.counter(pos) != 0 {
+			nonZero++
+		}
+	}
+	return math.Pow(float64(nonZero)/float64(f.m), float64(f.k))
+}
+
+// Reset clears all counters
+func (f *CountingBloomFilter) Reset() {
+	clear(f.counters)
+	f.count, f.saturated = 0, 0
+}
+
+func (f *CountingBloomFilter) add(h1, h2 uint64) bool {
+	ok := true
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		switch c := f.counter(pos); c {
+		case CounterMax:
+			ok = false
+		case CounterMax - 1:
+			f.setCounter(pos, CounterMax)
+			f.saturated++
+			ok = false
+		default:
+			f.setCounter(pos, c+1)
+		}
+	}
+	f.count++
+	return ok
+}
+
+func (f *CountingBloomFilter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < f.k; i++ {
+		if f.counter((h1+i*h2)%f.m) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *CountingBloomFilter) remove(h1, h2 uint64) error {
+	if !f.test(h1, h2) {
+		return ErrNotFound
+	}
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if c := f.counter(pos); c != 0 && c != CounterMax {
+			f.setCounter(pos, c-1)
+		}
+	}
+	if f.count > 0 {
+		f.count--
+	}
+	return nil
+}
+
+// counter value at position pos
+func (f *CountingBloomFilter) counter(pos uint64) byte {
+	return f.counters[pos/2] >> (4 * (pos % 2)) & 0x0f
+}
+
+// setCounter at position pos to v
+func (f *CountingBloomFilter) setCounter(pos uint64, v byte) {
+	shift := 4 * (pos % 2)
+	f.counters[pos/2] = f.counters[pos/2]&^(0x0f<<shift) | v<<shift
+}
@@ -0,0 +1,106 @@
+package bloomfilter
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCountingBloomFilter(t *testing.T) {
+	_, err := NewCountingBloomFilter(0, 0.01)
+	assert.Error(t, err)
+	_, err = NewCountingBloomFilter(100, 0)
+	assert.Error(t, err)
+
+	f, err := NewCountingBloomFilter(1000, 0.01)
+	assert.NoError(t, err)
+	// two counters per byte
+	assert.Equal(t, 4793, len(f.counters))
+}
+
+func TestCountingBloomFilter_AddRemove(t *testing.T) {
+	f, err := NewCountingBloomFilter(1000, 0.01)
+	assert.NoError(t, err)
+
+	assert.True(t, f.AddString("foo"))
+	assert.True(t, f.Add([]byte("bar")))
+	assert.True(t, f.AddString("foo"))
+	assert.Equal(t, uint64(3), f.Count())
+	assert.True(t, f.TestString("foo"))
+	assert.True(t, f.Test([]byte("bar")))
+
+	// each copy has to be removed
+	assert.NoError(t, f.RemoveString("foo"))
+	assert.True(t, f.TestString("foo"))
+	assert.NoError(t, f.Remove([]byte("foo")))
+	assert.False(t, f.TestString("foo"))
+	assert.True(t, f.TestString("bar"))
+
+	err = f.RemoveString("foo")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, uint64(1), f.Count())
+
+	assert.NoError(t, f.RemoveString("bar"))
+	assert.Equal(t, uint64(0), f.Count())
+	assert.Equal(t, float64(0), f.EstimatedFPR())
+	for _, b := range f.counters {
+		assert.Equal(t, byte(0), b)
+	}
+}
+
+func TestCountingBloomFilter_NoFalseNegatives(t *testing.T) {
+	const n = 2000
+	f, err := NewCountingBloomFilter(n, 0.01)
+	assert.NoError(t, err)
+	for i := 0; i < n; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	// remove odd items, even items must survive
+	for i := 1; i < n; i += 2 {
+		assert.NoError(t, f.RemoveString(strconv.Itoa(i)))
+	}
+	for i := 0; i < n; i += 2 {
+		assert.True(t, f.TestString(strconv.Itoa(i)))
+	}
+	assert.Equal(t, uint64(n/2), f.Count())
+	assert.InDelta(t, 0.01/16, f.EstimatedFPR(), 0.01)
+}
+
+func TestCountingBloomFilter_Saturation(t *testing.T) {
+	f, err := NewCountingBloomFilter(100, 0.01)
+	assert.NoError(t, err)
+
+	// counters reach CounterMax after 15 additions
+	for i := 0; i < CounterMax-1; i++ {
+		assert.True(t, f.AddString("foo"))
+	}
+	assert.Equal(t, uint64(0), f.Saturated())
+	assert.False(t, f.AddString("foo"))
+	assert.Equal(t, f.k, f.Saturated())
+
+	// saturated counters neither grow nor shrink
+	assert.False(t, f.AddString("foo"))
+	assert.Equal(t, f.k, f.Saturated())
+	for i := 0; i < 2*CounterMax; i++ {
+		assert.NoError(t, f.RemoveString("foo"))
+	}
+	assert.True(t, f.TestString("foo"))
+	assert.Equal(t, f.k, f.Saturated())
+
+	f.Reset()
+	assert.False(t, f.TestString("foo"))
+	assert.Equal(t, uint64(0), f.Saturated())
+}
+
+func TestCountingBloomFilter_Counters(t *testing.T) {
+	f, err := NewCountingBloomFilter(10, 0.1)
+	assert.NoError(t, err)
+	for pos := uint64(0); pos < f.m; pos++ {
+		f.setCounter(pos, byte(pos%16))
+	}
+	for pos := uint64(0); pos < f.m; pos++ {
+		assert.Equal(t, byte(pos%16), f.counter(pos))
+	}
+}
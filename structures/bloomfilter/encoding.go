@@ -0,0 +1,141 @@
+package bloomfilter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Binary format, all integers are little-endian:
+//
+//	magic    [4]byte  "BLMF"
+//	version  uint8
+//	m        uint64   number of bits
+//	k        uint64   number of hash functions
+//	seed     uint64
+//	bits     [(m+63)/64]uint64
+//	checksum uint32   CRC-32 (IEEE) of all preceding bytes
+
+const (
+	encodingVersion    uint8 = 1
+	encodingHeaderSize       = 4 + 1 + 8 + 8 + 8
+	encodingMaxHashes        = 64
+)
+
+var encodingMagic = [4]byte{'B', 'L', 'M', 'F'}
+
+// ErrInvalidData is returned when decoded data is corrupt
+// or does not describe a bloom filter
+var ErrInvalidData = errors.New("invalid bloom filter data")
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, encodingHeaderSize+len(f.bits)*8+4)
+	buf = append(buf, encodingMagic[:]...)
+	buf = append(buf, encodingVersion)
+	buf = binary.LittleEndian.AppendUint64(buf, f.m)
+	buf = binary.LittleEndian.AppendUint64(buf, f.k)
+	buf = binary.LittleEndian.AppendUint64(buf, f.seed)
+	for _, w := range f.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+// Filter is left unchanged if data is invalid
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	m, k, seed, err := decodeHeader(data)
+	if err != nil {
+		return err
+	}
+	words := (m + 63) / 64
+	if expected := uint64(encodingHeaderSize) + words*8 + 4; uint64(len(data)) != expected {
+		return fmt.Errorf("%w: length is %d bytes, expected %d for %d bits",
+			ErrInvalidData, len(data), expected, m)
+	}
+
+	payload, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if actual := crc32.ChecksumIEEE(payload); actual != sum {
+		return fmt.Errorf("%w: checksum mismatch: stored %08x, computed %08x",
+			ErrInvalidData, sum, actual)
+	}
+
+	filter := newBloomFilter(m, k, seed)
+	payload = payload[encodingHeaderSize:]
+	for j := range filter.bits {
+		filter.bits[j] = binary.LittleEndian.Uint64(payload[j*8:])
+	}
+	if tail := m % 64; tail != 0 && filter.bits[len(filter.bits)-1]>>tail != 0 {
+		return fmt.Errorf("%w: bits are set beyond filter size", ErrInvalidData)
+	}
+
+	*f = *filter
+	return nil
+}
+
+// WriteTo implements io.WriterTo
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom
+// It reads exactly one encoded filter from r
+func (f *BloomFilter) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, encodingHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil {
+		return int64(n), fmt.Errorf("%w: reading header: %w", ErrInvalidData, err)
+	}
+	m, _, _, err := decodeHeader(header)
+	if err != nil {
+		return int64(n), err
+	}
+
+	// payload is read through limited reader so corrupt header
+	// does not cause allocation of arbitrary size
+	var (
+		size = int64((m+63)/64*8 + 4)
+		buf  = bytes.NewBuffer(header)
+	)
+	read, err := io.Copy(buf, io.LimitReader(r, size))
+	if err != nil {
+		return int64(n) + read, err
+	}
+	if read != size {
+		return int64(n) + read, fmt.Errorf("%w: reading payload: %w", ErrInvalidData, io.ErrUnexpectedEOF)
+	}
+
+	return int64(n) + read, f.UnmarshalBinary(buf.Bytes())
+}
+
+// decodeHeader validates header and returns filter parameters
+func decodeHeader(data []byte) (m, k, seed uint64, err error) {
+	if len(data) < encodingHeaderSize {
+		return 0, 0, 0, fmt.Errorf("%w: data is too short (%d bytes)", ErrInvalidData, len(data))
+	}
+	if !bytes.Equal(data[:4], encodingMagic[:]) {
+		return 0, 0, 0, fmt.Errorf("%w: unknown magic %q", ErrInvalidData, data[:4])
+	}
+	if v := data[4]; v != encodingVersion {
+		return 0, 0, 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, v)
+	}
+	m = binary.LittleEndian.Uint64(data[5:])
+	k = binary.LittleEndian.Uint64(data[13:])
+	seed = binary.LittleEndian.Uint64(data[21:])
+	if m == 0 || m > 1<<48 {
+		return 0, 0, 0, fmt.Errorf("%w: invalid number of bits %d", ErrInvalidData, m)
+	}
+	if k == 0 || k > encodingMaxHashes {
+		return 0, 0, 0, fmt.Errorf("%w: invalid number of hash functions %d", ErrInvalidData, k)
+	}
+	return m, k, seed, nil
+}
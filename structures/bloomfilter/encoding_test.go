@@ -0,0 +1,101 @@
+package bloomfilter
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFilter(t *testing.T, seed uint64) *BloomFilter {
+	f, err := NewBloomFilterWithSeed(1000, 0.01, seed)
+	assert.NoError(t, err)
+	for i := 0; i < 500; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	return f
+}
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	f := newTestFilter(t, 42)
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var decoded BloomFilter
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, f, &decoded)
+	for i := 0; i < 500; i++ {
+		assert.True(t, decoded.TestString(strconv.Itoa(i)))
+	}
+
+	// different seed produces different bits for same items
+	other, err := newTestFilter(t, 43).MarshalBinary()
+	assert.NoError(t, err)
+	assert.NotEqual(t, data, other)
+}
+
+func TestBloomFilter_UnmarshalBinary_Invalid(t *testing.T) {
+	data, err := newTestFilter(t, 42).MarshalBinary()
+	assert.NoError(t, err)
+
+	corrupt := func(fn func(b []byte) []byte) []byte {
+		return fn(bytes.Clone(data))
+	}
+	tests := map[string][]byte{
+		"empty":     nil,
+		"truncated": data[:len(data)-1],
+		"trailing":  append(bytes.Clone(data), 0),
+		"magic":     corrupt(func(b []byte) []byte { b[0] = 'X'; return b }),
+		"version":   corrupt(func(b []byte) []byte { b[4] = 99; return b }),
+		"bits":      corrupt(func(b []byte) []byte { b[5]++; return b }),
+		"hashes":    corrupt(func(b []byte) []byte { b[13] = 0; return b }),
+		"payload":   corrupt(func(b []byte) []byte { b[encodingHeaderSize] ^= 1; return b }),
+		"checksum":  corrupt(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }),
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFilter(t, 7)
+			// copy of bits, struct copy would share them with f
+			before := *f
+			before.bits = slices.Clone(f.bits)
+			err := f.UnmarshalBinary(input)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidData))
+			assert.Equal(t, before, *f)
+		})
+	}
+}
+
+func TestBloomFilter_WriteToReadFrom(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		first  = newTestFilter(t, 1)
+		second = newTestFilter(t, 2)
+	)
+	n1, err := first.WriteTo(&buf)
+	assert.NoError(t, err)
+	n2, err := second.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n1+n2)
+
+	var decoded BloomFilter
+	n, err := decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n1, n)
+	assert.Equal(t, first, &decoded)
+
+	n, err = decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n2, n)
+	assert.Equal(t, second, &decoded)
+
+	_, err = decoded.ReadFrom(&buf)
+	assert.True(t, errors.Is(err, ErrInvalidData))
+
+	data, _ := first.MarshalBinary()
+	_, err = decoded.ReadFrom(bytes.NewReader(data[:len(data)-10]))
+	assert.True(t, errors.Is(err, ErrInvalidData))
+}
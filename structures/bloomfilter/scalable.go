@@ -0,0 +1,143 @@
+package bloomfilter
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// ScalableGrowth is capacity multiplier of each next sub-filter
+	ScalableGrowth = 2
+	// ScalableTightening is false-positive probability ratio of each next sub-filter
+	ScalableTightening = 0.9
+)
+
+// ScalableBloomFilter is a chain of bloom filters which grows as items arrive
+// Each new sub-filter has larger capacity and tighter false-positive probability,
+// so compound false-positive probability stays bounded by
+//
+//	P <= p0 * 1/(1-r)
+//
+// https://gsd.di.uminho.pt/members/cbm/ps/dbloom.pdf
+type ScalableBloomFilter struct {
+	filters  []*BloomFilter
+	n0       int     // capacity of first sub-filter
+	p0       float64 // false-positive probability of first sub-filter
+	capacity int     // capacity of last sub-filter
+	count    int     // items added to last sub-filter
+	seed     uint64
+}
+
+// NewScalableBloomFilter creates filter with first sub-filter holding n items
+// and compound false-positive probability not exceeding p
+func NewScalableBloomFilter(n int, p float64) (*ScalableBloomFilter, error) {
+	if n <= 0 {
+		return nil, errors.New("initial number of items must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		return nil, errors.New("false-positive probability must be in range (0, 1)")
+	}
+	f := &ScalableBloomFilter{
+		n0: n,
+		// geometric series p0 + p0*r + p0*r^2 ... converges to p
+		p0:   p * (1 - ScalableTightening),
+		seed: defaultSeed,
+	}
+	f.grow()
+	return f, nil
+}
+
+// SubFilters returns number of sub-filters in chain
+func (f *ScalableBloomFilter) SubFilters() int {
+	return len(f.filters)
+}
+
+// Add data to filter
+// Items which are already reported as present are not added again,
+// so duplicates do not consume capacity
+func (f *ScalableBloomFilter) Add(data []byte) {
+	if f.Test(data) {
+		return
+	}
+	f.prepare()
+	f.filters[len(f.filters)-1].Add(data)
+	f.count++
+}
+
+// AddString adds string to filter
+func (f *ScalableBloomFilter) AddString(s string) {
+	if f.TestString(s) {
+		return
+	}
+	f.prepare()
+	f.filters[len(f.filters)-1].AddString(s)
+	f.count++
+}
+
+// Test returns true if data is possibly in filter
+func (f *ScalableBloomFilter) Test(data []byte) bool {
+	for j := len(f.filters) - 1; j >= 0; j-- {
+		if f.filters[j].Test(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestString returns true if string is possibly in filter
+func (f *ScalableBloomFilter) TestString(s string) bool {
+	for j := len(f.filters) - 1; j >= 0; j-- {
+		if f.filters[j].TestString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// EstimatedFPR returns current compound false-positive probability
+//
+//	P = 1 - (1-P0)(1-P1)...(1-Pn)
+func (f *ScalableBloomFilter) EstimatedFPR() float64 {
+	var notFP = 1.0
+	for _, sf := range f.filters {
+		notFP *= 1 - sf.EstimatedFPR()
+	}
+	return 1 - notFP
+}
+
+// ApproximateCount of distinct items added to filter
+func (f *ScalableBloomFilter) ApproximateCount() uint64 {
+	var cnt uint64
+	for _, sf := range f.filters {
+		cnt += sf.ApproximateCount()
+	}
+	return cnt
+}
+
+// Reset drops all sub-filters and starts over with an empty first one
+func (f *ScalableBloomFilter) Reset() {
+	f.filters = f.filters[:0]
+	f.grow()
+}
+
+// prepare appends new sub-filter if last one reached its capacity
+func (f *ScalableBloomFilter) prepare() {
+	if f.count >= f.capacity {
+		f.grow()
+	}
+}
+
+// grow appends new sub-filter to chain
+// i-th sub-filter holds n0*s^i items with false-positive probability p0*r^i
+func (f *ScalableBloomFilter) grow() {
+	var (
+		i   = len(f.filters)
+		fpr = f.p0 * math.Pow(ScalableTightening, float64(i))
+	)
+	f.capacity = f.n0 * int(math.Pow(ScalableGrowth, float64(i)))
+	m, k := OptimalParameters(f.capacity, fpr)
+	// each sub-filter gets its own seed, so items colliding
+	// in one sub-filter are unlikely to collide in another
+	f.filters = append(f.filters, newBloomFilter(m, k, f.seed+uint64(i)))
+	f.count = 0
+}
@@ -0,0 +1,78 @@
+package bloomfilter
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScalableBloomFilter(t *testing.T) {
+	_, err := NewScalableBloomFilter(0, 0.01)
+	assert.Error(t, err)
+	_, err = NewScalableBloomFilter(100, 1)
+	assert.Error(t, err)
+
+	f, err := NewScalableBloomFilter(100, 0.01)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, f.SubFilters())
+}
+
+func TestScalableBloomFilter_Grow(t *testing.T) {
+	f, err := NewScalableBloomFilter(100, 0.01)
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	assert.Equal(t, 1, f.SubFilters())
+
+	// duplicates do not consume capacity
+	for i := 0; i < 100; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	assert.Equal(t, 1, f.SubFilters())
+
+	// capacity doubles: 100, 200, 400, 800
+	f.AddString("foo")
+	assert.Equal(t, 2, f.SubFilters())
+	for i := 100; i < 1500; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	assert.Equal(t, 4, f.SubFilters())
+	assert.Equal(t, 800, f.capacity)
+
+	for i := 0; i < 1500; i++ {
+		assert.True(t, f.TestString(strconv.Itoa(i)))
+		assert.True(t, f.Test([]byte(strconv.Itoa(i))))
+	}
+	assert.True(t, f.TestString("foo"))
+	assert.InDelta(t, 1500, f.ApproximateCount(), 1500*0.05)
+
+	f.Reset()
+	assert.Equal(t, 1, f.SubFilters())
+	assert.False(t, f.TestString("foo"))
+	assert.Equal(t, uint64(0), f.ApproximateCount())
+}
+
+func TestScalableBloomFilter_FalsePositiveRate(t *testing.T) {
+	const (
+		n = 50000
+		p = 0.01
+	)
+	f, err := NewScalableBloomFilter(100, p)
+	assert.NoError(t, err)
+	for i := 0; i < n; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	assert.Greater(t, f.SubFilters(), 5)
+
+	var fp int
+	for i := n; i < 3*n; i++ {
+		if f.TestString(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	assert.Less(t, float64(fp)/float64(2*n), p)
+	assert.Less(t, f.EstimatedFPR(), p)
+}
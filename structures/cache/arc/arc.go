@@ -0,0 +1,186 @@
+//
+// Package arc implements Adaptive Replacement Cache.
+//
+// Cache keeps two LRU lists: T1 of entries seen once and T2 of entries seen
+// at least twice, together with ghost lists B1 and B2 of keys recently evicted
+// from them. Put of a ghost key tells which list was evicted too early and
+// shifts target size of T1, so cache adapts between recency and frequency.
+//
+// https://en.wikipedia.org/wiki/Adaptive_replacement_cache
+// https://www.usenix.org/legacy/events/fast03/tech/full_papers/megiddo/megiddo.pdf
+//
+package arc
+
+import (
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+var _ cache.Cache[string, int] = (*Cache[string, int])(nil)
+
+// Cache is ARC cache implementation
+// It is not safe for concurrent use
+type Cache[K comparable, V any] struct {
+	size   int
+	target int                      // adaptive target size of T1
+	t1, t2 *list.List[*entry[K, V]] // resident entries in LRU order
+	b1, b2 *list.List[K]            // ghost keys in LRU order
+	data   map[K]*list.Element[*entry[K, V]]
+	ghosts map[K]*list.Element[K]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewCache creates new instance of ARC cache of given size
+// Cache of size 0 or less keeps nothing
+func NewCache[K comparable, V any](size int) *Cache[K, V] {
+	size = max(size, 0)
+	return &Cache[K, V]{
+		size:   size,
+		t1:     list.New[*entry[K, V]](),
+		t2:     list.New[*entry[K, V]](),
+		b1:     list.New[K](),
+		b2:     list.New[K](),
+		data:   make(map[K]*list.Element[*entry[K, V]], size),
+		ghosts: make(map[K]*list.Element[K], size),
+	}
+}
+
+// Len returns size of cached data
+func (c *Cache[K, V]) Len() int {
+	return len(c.data)
+}
+
+// Put a key-value pair into cache, it will update entry
+// if it already exists and move it to T2
+// Ghost key adapts target size of T1 and its entry goes to T2,
+// new key goes to T1
+func (c *Cache[K, V]) Put(key K, value V) {
+	if e, ok := c.data[key]; ok {
+		e.Value.value = value
+		c.promote(e)
+		return
+	}
+	if c.size == 0 {
+		return
+	}
+
+	if g, ok := c.ghosts[key]; ok {
+		inB2 := g.List() == c.b2
+		if inB2 {
+			// entry of T2 was evicted too early, T1 should shrink
+			c.target = max(c.target-max(c.b1.Len()/c.b2.Len(), 1), 0)
+		} else {
+			// entry of T1 was evicted too early, T1 should grow
+			c.target = min(c.target+max(c.b2.Len()/c.b1.Len(), 1), c.size)
+		}
+		c.forget(g)
+		if len(c.data) == c.size {
+			c.replace(inB2)
+		}
+		c.data[key] = c.t2.PushBack(&entry[K, V]{key: key, value: value})
+		return
+	}
+
+	switch {
+	case c.t1.Len()+c.b1.Len() >= c.size:
+		if c.b1.Len() > 0 {
+			c.forget(c.b1.Front())
+			if len(c.data) == c.size {
+				c.replace(false)
+			}
+		} else {
+			// T1 takes whole cache, drop its entry without remembering
+			delete(c.data, c.t1.Remove(c.t1.Front()).key)
+		}
+	case len(c.data)+len(c.ghosts) >= c.size:
+		if len(c.data)+len(c.ghosts) >= 2*c.size {
+			c.forget(c.b2.Front())
+		}
+		if len(c.data) == c.size {
+			c.replace(false)
+		}
+	}
+	c.data[key] = c.t1.PushBack(&entry[K, V]{key: key, value: value})
+}
+
+// Get a value by key, return false if value not found
+// This operation will make entry most recently used entry of T2
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if e, ok := c.data[key]; ok {
+		c.promote(e)
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek a value by key without making entry most recently used
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.data[key]; ok {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains tests if key is in cache without making entry most recently used
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.data[key]
+	return ok
+}
+
+// Delete entry by key, return false if it was not in cache
+// Key is not remembered in ghost lists
+func (c *Cache[K, V]) Delete(key K) bool {
+	e, ok := c.data[key]
+	if !ok {
+		return false
+	}
+	delete(c.data, key)
+	e.List().Remove(e)
+	return true
+}
+
+// Purge removes all entries and ghost keys from cache
+func (c *Cache[K, V]) Purge() {
+	c.t1.Clear()
+	c.t2.Clear()
+	c.b1.Clear()
+	c.b2.Clear()
+	clear(c.data)
+	clear(c.ghosts)
+	c.target = 0
+}
+
+// promote resident entry to most recently used entry of T2
+func (c *Cache[K, V]) promote(e *list.Element[*entry[K, V]]) {
+	if e.List() == c.t2 {
+		c.t2.MoveToBack(e)
+		return
+	}
+	c.t1.Remove(e)
+	c.data[e.Value.key] = c.t2.PushBack(e.Value)
+}
+
+// replace evicts least recently used entry of T1 or T2 depending on
+// target size of T1 and remembers its key in corresponding ghost list
+func (c *Cache[K, V]) replace(inB2 bool) {
+	if t1 := c.t1.Len(); t1 > 0 && (t1 > c.target || (inB2 && t1 == c.target) || c.t2.Len() == 0) {
+		e := c.t1.Remove(c.t1.Front())
+		delete(c.data, e.key)
+		c.ghosts[e.key] = c.b1.PushBack(e.key)
+		return
+	}
+	e := c.t2.Remove(c.t2.Front())
+	delete(c.data, e.key)
+	c.ghosts[e.key] = c.b2.PushBack(e.key)
+}
+
+// forget ghost key
+func (c *Cache[K, V]) forget(g *list.Element[K]) {
+	delete(c.ghosts, g.List().Remove(g))
+}
@@ -0,0 +1,135 @@
+package arc
+
+import (
+	"testing"
+
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func keys[T any](t *testing.T, l *list.List[T], key func(T) string) []string {
+	assert.NoError(t, l.Validate())
+	var ret []string
+	for e := l.Front(); e != nil; e = e.Next() {
+		ret = append(ret, key(e.Value))
+	}
+	return ret
+}
+
+// lists returns keys of T1, T2, B1 and B2
+func lists(t *testing.T, c *Cache[string, int]) (t1, t2, b1, b2 []string) {
+	var (
+		entryKey = func(e *entry[string, int]) string { return e.key }
+		ghostKey = func(k string) string { return k }
+	)
+	return keys(t, c.t1, entryKey), keys(t, c.t2, entryKey), keys(t, c.b1, ghostKey), keys(t, c.b2, ghostKey)
+}
+
+func TestARCCache(t *testing.T) {
+	c := NewCache[string, int](4)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		c.Put(k, 1)
+	}
+	c.Get("a")
+	c.Put("b", 2)
+	t1, t2, b1, b2 := lists(t, c)
+	assert.Equal(t, []string{"c", "d"}, t1)
+	assert.Equal(t, []string{"a", "b"}, t2)
+	assert.Empty(t, b1)
+	assert.Empty(t, b2)
+
+	// T1 is over its target of 0, its entries become ghosts
+	c.Put("e", 1)
+	c.Put("f", 1)
+	t1, t2, b1, _ = lists(t, c)
+	assert.Equal(t, []string{"e", "f"}, t1)
+	assert.Equal(t, []string{"a", "b"}, t2)
+	assert.Equal(t, []string{"c", "d"}, b1)
+	_, ok := c.Get("c")
+	assert.False(t, ok)
+
+	// ghost hit in B1 grows target of T1
+	c.Put("c", 3)
+	assert.Equal(t, 1, c.target)
+	t1, t2, b1, _ = lists(t, c)
+	assert.Equal(t, []string{"f"}, t1)
+	assert.Equal(t, []string{"a", "b", "c"}, t2)
+	assert.Equal(t, []string{"d", "e"}, b1)
+
+	// T1 fits its target, T2 is evicted
+	c.Put("g", 1)
+	t1, t2, b1, b2 = lists(t, c)
+	assert.Equal(t, []string{"f", "g"}, t1)
+	assert.Equal(t, []string{"b", "c"}, t2)
+	assert.Equal(t, []string{"d", "e"}, b1)
+	assert.Equal(t, []string{"a"}, b2)
+
+	// ghost hit in B2 shrinks target of T1
+	c.Put("a", 4)
+	assert.Equal(t, 0, c.target)
+	t1, t2, b1, b2 = lists(t, c)
+	assert.Equal(t, []string{"g"}, t1)
+	assert.Equal(t, []string{"b", "c", "a"}, t2)
+	assert.Equal(t, []string{"d", "e", "f"}, b1)
+	assert.Empty(t, b2)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+	v, ok = c.Peek("g")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.True(t, c.Contains("b"))
+	assert.False(t, c.Contains("e"))
+
+	assert.True(t, c.Delete("g"))
+	assert.False(t, c.Delete("e"))
+	assert.Equal(t, 3, c.Len())
+	c.Put("h", 1)
+	assert.Equal(t, 4, c.Len())
+	assert.LessOrEqual(t, c.Len()+len(c.ghosts), 8)
+
+	c.Purge()
+	t1, t2, b1, b2 = lists(t, c)
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, append(append(append(t1, t2...), b1...), b2...))
+	assert.Empty(t, c.ghosts)
+	assert.Equal(t, 0, c.target)
+}
+
+// TestARCCache_Bounds runs access pattern which shifts target back and forth
+// and checks sizes of lists
+func TestARCCache_Bounds(t *testing.T) {
+	const size = 8
+	c := NewCache[int, int](size)
+	for i := 0; i < 10000; i++ {
+		k := i % 13
+		if i%3 == 0 {
+			k = i % 29
+		}
+		if _, ok := c.Get(k); !ok {
+			c.Put(k, k)
+		}
+		if i%101 == 0 {
+			c.Delete(k)
+		}
+		assert.LessOrEqual(t, c.Len(), size)
+		assert.Equal(t, c.Len(), c.t1.Len()+c.t2.Len())
+		assert.Equal(t, len(c.ghosts), c.b1.Len()+c.b2.Len())
+		assert.LessOrEqual(t, c.t1.Len()+c.b1.Len(), size)
+		assert.LessOrEqual(t, c.Len()+len(c.ghosts), 2*size)
+		assert.LessOrEqual(t, c.target, size)
+	}
+}
+
+func TestARCCache_SmallCache(t *testing.T) {
+	c := NewCache[string, int](0)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+
+	c = NewCache[string, int](1)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	assert.Equal(t, 1, c.Len())
+	assert.True(t, c.Contains("b"))
+}
@@ -0,0 +1,21 @@
+package cache
+
+// Cache is common interface of cache policies
+// Implementations are not safe for concurrent use unless stated otherwise
+type Cache[K comparable, V any] interface {
+	// Len returns number of cached entries
+	Len() int
+	// Put a key-value pair into cache, it will update entry if it already exists
+	Put(key K, value V)
+	// Get a value by key, return false if value not found
+	// This operation counts as access to entry
+	Get(key K) (V, bool)
+	// Peek a value by key without counting it as access
+	Peek(key K) (V, bool)
+	// Contains tests if key is in cache without counting it as access
+	Contains(key K) bool
+	// Delete entry by key, return false if it was not in cache
+	Delete(key K) bool
+	// Purge removes all entries from cache
+	Purge()
+}
@@ -0,0 +1,160 @@
+package cache_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/arc"
+	"github.com/hasansino/gobasics/structures/cache/lfu"
+	"github.com/hasansino/gobasics/structures/cache/lru"
+	"github.com/hasansino/gobasics/structures/cache/tinylfu"
+	"github.com/hasansino/gobasics/structures/cache/twoq"
+	"github.com/stretchr/testify/assert"
+)
+
+var policies = map[string]func(size int) cache.Cache[int, int]{
+	"lru":     func(size int) cache.Cache[int, int] { return lru.NewCache[int, int](size) },
+	"lfu":     func(size int) cache.Cache[int, int] { return lfu.NewCache[int, int](size) },
+	"2q":      func(size int) cache.Cache[int, int] { return twoq.NewCache[int, int](size) },
+	"arc":     func(size int) cache.Cache[int, int] { return arc.NewCache[int, int](size) },
+	"tinylfu": func(size int) cache.Cache[int, int] { return tinylfu.NewCache[int, int](size) },
+}
+
+func TestCache_Policies(t *testing.T) {
+	for name, newCache := range policies {
+		c := newCache(10)
+		for k := 0; k < 100; k++ {
+			c.Put(k, k)
+			assert.LessOrEqual(t, c.Len(), 10, name)
+		}
+		assert.Equal(t, 10, c.Len(), name)
+
+		c.Put(100, 100)
+		c.Put(100, -100)
+		for k := 0; k <= 100; k++ {
+			v, ok := c.Peek(k)
+			assert.Equal(t, ok, c.Contains(k), name)
+			got, gotOk := c.Get(k)
+			assert.Equal(t, ok, gotOk, name)
+			assert.Equal(t, v, got, name)
+			if ok && k < 100 {
+				assert.Equal(t, k, v, name)
+			}
+		}
+		if v, ok := c.Get(100); ok {
+			assert.Equal(t, -100, v, name)
+		}
+
+		for k := 0; k <= 100; k++ {
+			ok := c.Contains(k)
+			assert.Equal(t, ok, c.Delete(k), name)
+			assert.False(t, c.Contains(k), name)
+		}
+		assert.Equal(t, 0, c.Len(), name)
+
+		c.Put(1, 1)
+		c.Purge()
+		assert.Equal(t, 0, c.Len(), name)
+		_, ok := c.Get(1)
+		assert.False(t, ok, name)
+
+		c = newCache(0)
+		c.Put(1, 1)
+		assert.Equal(t, 0, c.Len(), name)
+	}
+}
+
+// traces of key accesses, every trace has 1<<17 accesses
+var traces = map[string]func(rnd *rand.Rand) []int{
+	// zipf is skewed popularity typical for web caches
+	"zipf": func(rnd *rand.Rand) []int {
+		zipf := rand.NewZipf(rnd, 1.1, 1, 1<<16)
+		trace := make([]int, 1<<17)
+		for i := range trace {
+			trace[i] = int(zipf.Uint64())
+		}
+		return trace
+	},
+	// scan is zipf interleaved with sequential reads of keys never seen again
+	"scan": func(rnd *rand.Rand) []int {
+		var (
+			zipf  = rand.NewZipf(rnd, 1.1, 1, 1<<16)
+			trace = make([]int, 1<<17)
+			next  = 1 << 20
+		)
+		for i := 0; i < len(trace); {
+			for j := 0; j < 4000 && i < len(trace); j, i = j+1, i+1 {
+				trace[i] = int(zipf.Uint64())
+			}
+			for j := 0; j < 1000 && i < len(trace); j, i = j+1, i+1 {
+				trace[i] = next
+				next++
+			}
+		}
+		return trace
+	},
+	// loop is cyclic access to slightly more keys than cache holds,
+	// worst case for lru
+	"loop": func(rnd *rand.Rand) []int {
+		trace := make([]int, 1<<17)
+		for i := range trace {
+			trace[i] = i % (traceCacheSize + traceCacheSize/4)
+		}
+		return trace
+	},
+}
+
+// traceCacheSize is size of caches replaying traces
+const traceCacheSize = 1000
+
+// hitRatio replays trace on cache, every miss is followed by Put
+func hitRatio(c cache.Cache[int, int], trace []int) float64 {
+	var hits int
+	for _, k := range trace {
+		if _, ok := c.Get(k); ok {
+			hits++
+		} else {
+			c.Put(k, k)
+		}
+	}
+	return float64(hits) / float64(len(trace))
+}
+
+func TestCache_HitRatio(t *testing.T) {
+	var (
+		scan = traces["scan"](rand.New(rand.NewPCG(1, 2)))
+		loop = traces["loop"](rand.New(rand.NewPCG(1, 2)))
+		lru  = hitRatio(policies["lru"](traceCacheSize), scan)
+	)
+	assert.Equal(t, 0.0, hitRatio(policies["lru"](traceCacheSize), loop))
+	for name, newCache := range policies {
+		// tinylfu hashes keys with random seed and is checked
+		// with fixed hash by its own package tests
+		if name != "lru" && name != "tinylfu" {
+			// every other policy resists scans better
+			assert.Greater(t, hitRatio(newCache(traceCacheSize), scan), lru, name)
+		}
+	}
+	// policies which keep part of looped keys
+	assert.Greater(t, hitRatio(policies["2q"](traceCacheSize), loop), 0.5)
+}
+
+// BenchmarkCache_HitRatio reports hit ratio of every policy on every trace
+// as hit-ratio metric, run it with -benchtime=1x to compare policies
+func BenchmarkCache_HitRatio(b *testing.B) {
+	for traceName, newTrace := range traces {
+		trace := newTrace(rand.New(rand.NewPCG(1, 2)))
+		for name, newCache := range policies {
+			b.Run(fmt.Sprintf("%s/%s", traceName, name), func(b *testing.B) {
+				var ratio float64
+				for i := 0; i < b.N; i++ {
+					ratio = hitRatio(newCache(traceCacheSize), trace)
+				}
+				b.ReportMetric(ratio, "hit-ratio")
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(trace)), "ns/access")
+			})
+		}
+	}
+}
@@ -1,5 +1,11 @@
-// Package cache is implements number of caching techniques.
+// Package cache implements number of caching techniques.
+//
+// Every policy lives in its own package and implements Cache interface:
+//   - lru evicts least recently used entry
+//   - lfu evicts least frequently used entry
+//   - twoq keeps entries seen once apart from frequently used ones (2Q)
+//   - arc balances recency and frequency adaptively (ARC)
+//   - tinylfu admits entries by estimated frequency (W-TinyLFU)
 //
 // https://en.wikipedia.org/wiki/Cache_replacement_policies
-
 package cache
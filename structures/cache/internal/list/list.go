@@ -0,0 +1,154 @@
+//
+// Package list implements generic doubly linked list used by cache policies
+// to keep entries in recency order.
+//
+package list
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Element of list
+type Element[T any] struct {
+	Value T
+	prev  *Element[T]
+	next  *Element[T]
+	list  *List[T]
+}
+
+// Next returns element following e or nil if e is the last one
+func (e *Element[T]) Next() *Element[T] {
+	if e.list == nil || e.next == &e.list.root {
+		return nil
+	}
+	return e.next
+}
+
+// List returns list element belongs to or nil if it was removed
+func (e *Element[T]) List() *List[T] {
+	return e.list
+}
+
+// List is circular doubly linked list with sentinel root,
+// front is least recently used element by convention of cache policies
+type List[T any] struct {
+	root Element[T]
+	len  int
+}
+
+// New creates empty list
+func New[T any]() *List[T] {
+	l := &List[T]{}
+	l.root.next, l.root.prev = &l.root, &l.root
+	return l
+}
+
+// Len returns number of elements in list
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Front returns first element or nil if list is empty
+func (l *List[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns last element or nil if list is empty
+func (l *List[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront inserts value at the front of list
+func (l *List[T]) PushFront(v T) *Element[T] {
+	e := &Element[T]{Value: v}
+	l.insert(e, &l.root)
+	return e
+}
+
+// PushBack inserts value at the back of list
+func (l *List[T]) PushBack(v T) *Element[T] {
+	e := &Element[T]{Value: v}
+	l.insert(e, l.root.prev)
+	return e
+}
+
+// InsertAfter inserts value right after element of list
+// Returns nil if mark does not belong to list
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	e := &Element[T]{Value: v}
+	l.insert(e, mark)
+	return e
+}
+
+// Remove element from list and return its value
+// Element which does not belong to list is left untouched
+func (l *List[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		e.prev.next = e.next
+		e.next.prev = e.prev
+		e.prev, e.next, e.list = nil, nil, nil
+		l.len--
+	}
+	return e.Value
+}
+
+// MoveToBack moves element of list to its back
+func (l *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.Remove(e)
+	l.insert(e, l.root.prev)
+}
+
+// Clear removes all elements from list
+func (l *List[T]) Clear() {
+	for e := l.Front(); e != nil; e = l.Front() {
+		l.Remove(e)
+	}
+}
+
+// insert detached element after at
+func (l *List[T]) insert(e, at *Element[T]) {
+	e.prev, e.next, e.list = at, at.next, l
+	at.next.prev = e
+	at.next = e
+	l.len++
+}
+
+// Validate checks that links of list are consistent, every element
+// belongs to list and number of elements matches its length
+func (l *List[T]) Validate() error {
+	if l == nil || l.root.next == nil || l.root.prev == nil {
+		return errors.New("list is not initialized")
+	}
+	var n int
+	for e := &l.root; ; e = e.next {
+		if e.next == nil || e.next.prev != e {
+			return fmt.Errorf("broken list link after element %d", n)
+		}
+		if e.next == &l.root {
+			break
+		}
+		if e.next.list != l {
+			return fmt.Errorf("element %d belongs to other list", n)
+		}
+		if n++; n > l.len {
+			return fmt.Errorf("list has more than %d elements", l.len)
+		}
+	}
+	if n != l.len {
+		return fmt.Errorf("list has %d elements, expected %d", n, l.len)
+	}
+	return nil
+}
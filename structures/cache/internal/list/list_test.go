@@ -0,0 +1,97 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func values(l *List[int]) []int {
+	var ret []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		ret = append(ret, e.Value)
+	}
+	return ret
+}
+
+func TestList(t *testing.T) {
+	l := New[int]()
+	assert.Equal(t, 0, l.Len())
+	assert.Nil(t, l.Front())
+	assert.Nil(t, l.Back())
+
+	e1 := l.PushBack(1)
+	e2 := l.PushBack(2)
+	e3 := l.PushBack(3)
+	assert.Equal(t, []int{1, 2, 3}, values(l))
+	assert.Equal(t, e1, l.Front())
+	assert.Equal(t, e3, l.Back())
+	assert.Equal(t, l, e2.List())
+
+	l.MoveToBack(e1)
+	l.MoveToBack(e1)
+	assert.Equal(t, []int{2, 3, 1}, values(l))
+
+	assert.Equal(t, 3, l.Remove(e3))
+	assert.Nil(t, e3.List())
+	assert.Nil(t, e3.Next())
+	assert.Equal(t, []int{2, 1}, values(l))
+
+	// element of other list is not touched
+	other := New[int]()
+	l.Remove(other.PushBack(4))
+	l.MoveToBack(e3)
+	assert.Equal(t, 2, l.Len())
+	assert.Equal(t, 1, other.Len())
+
+	l.Clear()
+	assert.Equal(t, 0, l.Len())
+	assert.Nil(t, values(l))
+	l.PushBack(5)
+	assert.Equal(t, []int{5}, values(l))
+
+	e0 := l.PushFront(0)
+	l.InsertAfter(6, l.Back())
+	l.InsertAfter(3, e0)
+	assert.Equal(t, []int{0, 3, 5, 6}, values(l))
+	assert.Nil(t, l.InsertAfter(7, e3))
+	assert.Nil(t, l.InsertAfter(7, other.Front()))
+	assert.Equal(t, 4, l.Len())
+	assert.NoError(t, l.Validate())
+}
+
+func TestList_Validate(t *testing.T) {
+	newList := func() *List[int] {
+		l := New[int]()
+		for v := 0; v < 5; v++ {
+			l.PushBack(v)
+		}
+		assert.NoError(t, l.Validate())
+		return l
+	}
+
+	l := newList()
+	l.root.next.next.prev = &Element[int]{}
+	assert.Error(t, l.Validate())
+
+	l = newList()
+	l.len++
+	assert.Error(t, l.Validate())
+
+	l = newList()
+	l.len--
+	assert.Error(t, l.Validate())
+
+	l = newList()
+	l.Front().list = New[int]()
+	assert.Error(t, l.Validate())
+
+	l = newList()
+	// cycle which skips sentinel
+	l.root.prev.next = l.root.next
+	assert.Error(t, l.Validate())
+
+	assert.Error(t, (&List[int]{}).Validate())
+	assert.Error(t, (*List[int])(nil).Validate())
+	assert.NoError(t, New[int]().Validate())
+}
@@ -0,0 +1,159 @@
+//
+// Package lfu implements LFU Cache with O(1) operations.
+//
+// Entries are grouped into buckets of equal access frequency,
+// buckets are kept in increasing order of frequency and entries of
+// every bucket in order of last access. Eviction takes least recently
+// used entry of the lowest frequency bucket.
+//
+// https://en.wikipedia.org/wiki/Least_frequently_used
+// http://dhruvbird.com/lfu.pdf
+//
+package lfu
+
+import (
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+var _ cache.Cache[string, int] = (*Cache[string, int])(nil)
+
+// Cache is LFU cache implementation
+// It is not safe for concurrent use
+type Cache[K comparable, V any] struct {
+	size    int
+	buckets *list.List[*bucket[K, V]] // in increasing order of frequency
+	data    map[K]*list.Element[*entry[K, V]]
+}
+
+// bucket holds entries with the same frequency
+type bucket[K comparable, V any] struct {
+	freq    int
+	entries *list.List[*entry[K, V]] // from least to most recently used
+}
+
+type entry[K comparable, V any] struct {
+	key    K
+	value  V
+	bucket *list.Element[*bucket[K, V]]
+}
+
+// NewCache creates new instance of LFU cache of given size
+// Cache of size 0 or less keeps nothing
+func NewCache[K comparable, V any](size int) *Cache[K, V] {
+	size = max(size, 0)
+	return &Cache[K, V]{
+		size:    size,
+		buckets: list.New[*bucket[K, V]](),
+		data:    make(map[K]*list.Element[*entry[K, V]], size),
+	}
+}
+
+// Len returns size of cached data
+func (c *Cache[K, V]) Len() int {
+	return len(c.data)
+}
+
+// Put a key-value pair into cache, it will update entry
+// if it already exists and increase its frequency
+func (c *Cache[K, V]) Put(key K, value V) {
+	if el, ok := c.data[key]; ok {
+		el.Value.value = value
+		c.touch(el)
+		return
+	}
+	if c.size == 0 {
+		return
+	}
+	if len(c.data) == c.size {
+		// least recently used entry of lowest frequency
+		c.remove(c.buckets.Front().Value.entries.Front())
+	}
+	b := c.buckets.Front()
+	if b == nil || b.Value.freq != 1 {
+		b = c.buckets.PushFront(newBucket[K, V](1))
+	}
+	c.data[key] = b.Value.entries.PushBack(&entry[K, V]{key: key, value: value, bucket: b})
+}
+
+// Get a value by key, return false if value not found
+// This operation will increase frequency of entry
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if el, ok := c.data[key]; ok {
+		c.touch(el)
+		return el.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek a value by key without increasing its frequency
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if el, ok := c.data[key]; ok {
+		return el.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains tests if key is in cache without increasing its frequency
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.data[key]
+	return ok
+}
+
+// Frequency returns number of accesses to entry, including Put
+// Returns 0 if key is not in cache
+func (c *Cache[K, V]) Frequency(key K) int {
+	if el, ok := c.data[key]; ok {
+		return el.Value.bucket.Value.freq
+	}
+	return 0
+}
+
+// Delete entry by key, return false if it was not in cache
+func (c *Cache[K, V]) Delete(key K) bool {
+	el, ok := c.data[key]
+	if ok {
+		c.remove(el)
+	}
+	return ok
+}
+
+// Purge removes all entries from cache
+func (c *Cache[K, V]) Purge() {
+	c.buckets.Clear()
+	clear(c.data)
+}
+
+// newBucket creates empty bucket of given frequency
+func newBucket[K comparable, V any](freq int) *bucket[K, V] {
+	return &bucket[K, V]{freq: freq, entries: list.New[*entry[K, V]]()}
+}
+
+// touch moves entry to bucket of next frequency
+func (c *Cache[K, V]) touch(el *list.Element[*entry[K, V]]) {
+	e, b := el.Value, el.Value.bucket
+	next := b.Next()
+	if next == nil || next.Value.freq != b.Value.freq+1 {
+		next = c.buckets.InsertAfter(newBucket[K, V](b.Value.freq+1), b)
+	}
+	c.unlink(el)
+	e.bucket = next
+	c.data[e.key] = next.Value.entries.PushBack(e)
+}
+
+// remove entry from cache
+func (c *Cache[K, V]) remove(el *list.Element[*entry[K, V]]) {
+	delete(c.data, el.Value.key)
+	c.unlink(el)
+}
+
+// unlink entry from its bucket, bucket is removed once it is empty
+func (c *Cache[K, V]) unlink(el *list.Element[*entry[K, V]]) {
+	b := el.Value.bucket
+	b.Value.entries.Remove(el)
+	if b.Value.entries.Len() == 0 {
+		c.buckets.Remove(b)
+	}
+}
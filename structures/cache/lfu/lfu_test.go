@@ -0,0 +1,90 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// frequencies lists frequency and keys of every bucket
+func frequencies(t *testing.T, c *Cache[string, int]) map[int][]string {
+	ret := make(map[int][]string)
+	assert.NoError(t, c.buckets.Validate())
+	prev := 0
+	for b := c.buckets.Front(); b != nil; b = b.Next() {
+		assert.Greater(t, b.Value.freq, prev)
+		assert.NoError(t, b.Value.entries.Validate())
+		assert.NotZero(t, b.Value.entries.Len())
+		for e := b.Value.entries.Front(); e != nil; e = e.Next() {
+			assert.Equal(t, b, e.Value.bucket)
+			assert.Equal(t, e, c.data[e.Value.key])
+			ret[b.Value.freq] = append(ret[b.Value.freq], e.Value.key)
+		}
+		prev = b.Value.freq
+	}
+	return ret
+}
+
+func TestLFUCache(t *testing.T) {
+	c := NewCache[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, map[int][]string{1: {"a", "b", "c"}}, frequencies(t, c))
+
+	c.Get("a")
+	c.Get("a")
+	c.Put("b", 20)
+	assert.Equal(t, map[int][]string{1: {"c"}, 2: {"b"}, 3: {"a"}}, frequencies(t, c))
+	assert.Equal(t, 3, c.Frequency("a"))
+	assert.Equal(t, 0, c.Frequency("d"))
+
+	// c is least frequently used
+	c.Put("d", 4)
+	assert.False(t, c.Contains("c"))
+	assert.Equal(t, map[int][]string{1: {"d"}, 2: {"b"}, 3: {"a"}}, frequencies(t, c))
+
+	// least recently used of equally frequent entries is evicted
+	c.Get("d")
+	assert.Equal(t, map[int][]string{2: {"b", "d"}, 3: {"a"}}, frequencies(t, c))
+	c.Put("e", 5)
+	assert.False(t, c.Contains("b"))
+	assert.Equal(t, map[int][]string{1: {"e"}, 2: {"d"}, 3: {"a"}}, frequencies(t, c))
+
+	// peek does not change frequency
+	v, ok := c.Peek("e")
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	assert.Equal(t, 1, c.Frequency("e"))
+	_, ok = c.Peek("b")
+	assert.False(t, ok)
+
+	v, ok = c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	assert.True(t, c.Delete("d"))
+	assert.False(t, c.Delete("d"))
+	assert.Equal(t, map[int][]string{1: {"e"}, 4: {"a"}}, frequencies(t, c))
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, frequencies(t, c))
+	c.Put("f", 6)
+	assert.Equal(t, map[int][]string{1: {"f"}}, frequencies(t, c))
+}
+
+func TestLFUCache_SmallCache(t *testing.T) {
+	c := NewCache[string, int](0)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+
+	c = NewCache[string, int](1)
+	c.Put("a", 1)
+	c.Get("a")
+	c.Put("b", 2)
+	assert.Equal(t, map[int][]string{1: {"b"}}, frequencies(t, c))
+}
@@ -0,0 +1,25 @@
+package lru
+
+// WithCost sets function which weighs entries, size of cache becomes
+// maximum total cost of its entries instead of their number
+// Cost should not change while entry is cached, cost below 1 is treated as 1,
+// so every entry takes part of capacity and size bounds number of entries
+func WithCost[K comparable, V any](cost func(key K, value V) int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.cost = cost
+	}
+}
+
+// Cost returns total cost of cached entries,
+// which is number of entries if cache has no cost function
+func (c *Cache[K, V]) Cost() int {
+	return c.used
+}
+
+// costOf entry, every entry costs 1 by default and at least 1 with cost function
+func (c *Cache[K, V]) costOf(key K, value V) int {
+	if c.cost == nil {
+		return 1
+	}
+	return max(c.cost(key, value), 1)
+}
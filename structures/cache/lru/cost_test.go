@@ -0,0 +1,159 @@
+package lru
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// byteCost weighs entry by length of its value
+func byteCost(_ string, value string) int {
+	return len(value)
+}
+
+func TestLRUCache_Cost(t *testing.T) {
+	var evicted []string
+	c := NewCache(10, WithCost(byteCost), WithOnEvict(func(key string, _ string, reason EvictReason) {
+		evicted = append(evicted, key+" "+reason.String())
+	}))
+	c.Put("a", "aaa")
+	c.Put("b", "bbbb")
+	c.Put("c", "cc")
+	assert.Equal(t, 9, c.Cost())
+	assert.Equal(t, 3, c.Len())
+
+	c.Put("d", "ddd")
+	assert.Equal(t, []string{"b", "c", "d"}, c.Keys())
+	assert.Equal(t, 9, c.Cost())
+
+	// as many entries as needed are evicted
+	assert.NoError(t, c.TryPut("e", strings.Repeat("e", 8)))
+	assert.Equal(t, []string{"e"}, c.Keys())
+	assert.Equal(t, 8, c.Cost())
+	assert.Equal(t, []string{"a capacity", "b capacity", "c capacity", "d capacity"}, evicted)
+
+	// entry larger than capacity is rejected
+	evicted = nil
+	assert.ErrorIs(t, c.TryPut("f", strings.Repeat("f", 11)), ErrTooLarge)
+	c.Put("f", strings.Repeat("f", 11))
+	assert.False(t, c.Contains("f"))
+	assert.Equal(t, []string{"e"}, c.Keys())
+	assert.Empty(t, evicted)
+
+	// updated entry fits whole cache
+	assert.NoError(t, c.TryPut("e", strings.Repeat("e", 10)))
+	assert.Equal(t, 10, c.Cost())
+	// old value is removed when update is rejected
+	assert.ErrorIs(t, c.TryPut("e", strings.Repeat("e", 11)), ErrTooLarge)
+	assert.False(t, c.Contains("e"))
+	assert.Equal(t, 0, c.Cost())
+	assert.Equal(t, []string{"e replaced", "e rejected"}, evicted)
+
+	// oversized Put leaves no trace of the key
+	evicted = nil
+	c.Put("e", "e")
+	c.Put("e", strings.Repeat("e", 11))
+	assert.False(t, c.Contains("e"))
+	_, ok := c.Peek("e")
+	assert.False(t, ok)
+	assert.Empty(t, c.Keys())
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.Cost())
+	assert.Equal(t, []string{"e rejected"}, evicted)
+	assert.NoError(t, c.Validate())
+
+	// growing update evicts other entries
+	evicted = nil
+	c.Put("g", "gg")
+	c.Put("h", "hh")
+	c.Put("g", strings.Repeat("g", 8))
+	assert.Equal(t, []string{"h", "g"}, c.Keys())
+	assert.Equal(t, 10, c.Cost())
+	c.Put("h", "hhh")
+	assert.Equal(t, []string{"h"}, c.Keys())
+	assert.Equal(t, 3, c.Cost())
+	assert.Equal(t, []string{"g replaced", "h replaced", "g capacity"}, evicted)
+
+	c.Put("i", "iiii")
+	c.Resize(5)
+	assert.Equal(t, []string{"i"}, c.Keys())
+	assert.Equal(t, 4, c.Cost())
+	assert.NoError(t, c.Validate())
+
+	c.Delete("i")
+	assert.Equal(t, 0, c.Cost())
+	c.Put("j", "j")
+	c.Purge()
+	assert.Equal(t, 0, c.Cost())
+	assert.NoError(t, c.Validate())
+}
+
+func TestLRUCache_CostEdgeCases(t *testing.T) {
+	// zero and negative cost is treated as 1
+	c := NewCache(2, WithCost(func(_ string, v int) int { return v }))
+	c.Put("a", -5)
+	c.Put("b", 0)
+	assert.Equal(t, []string{"a", "b"}, c.Keys())
+	assert.Equal(t, 2, c.Cost())
+	c.Put("c", 1)
+	assert.Equal(t, []string{"b", "c"}, c.Keys())
+	assert.Equal(t, 2, c.Cost())
+	assert.NoError(t, c.Validate())
+
+	// entries are evicted in recency order regardless of their cost
+	c.Resize(0)
+	assert.Empty(t, c.Keys())
+	assert.ErrorIs(t, c.TryPut("d", 1), ErrTooLarge)
+
+	// capacity in bytes does not preallocate entries
+	c = NewCache(1<<40, WithCost(func(_ string, v int) int { return v }))
+	assert.NoError(t, c.TryPut("a", 1<<30))
+	assert.Equal(t, 1<<30, c.Cost())
+
+	// size is number of entries without cost function
+	d := NewCache[string, string](2)
+	d.Put("a", strings.Repeat("a", 100))
+	d.Put("b", "b")
+	assert.Equal(t, 2, d.Cost())
+}
+
+func TestLRUCache_ZeroCost(t *testing.T) {
+	zero := WithCost(func(string, int) int { return 0 })
+
+	// cache of size 0 keeps nothing
+	c := NewCache(0, zero)
+	for i := 0; i < 100; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 0, c.Len())
+	assert.ErrorIs(t, c.TryPut("a", 1), ErrTooLarge)
+
+	// zero cost entries do not grow cache over its size
+	c = NewCache(1, zero)
+	for i := 0; i < 100; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, []string{"99"}, c.Keys())
+	assert.NoError(t, c.Validate())
+}
+
+func TestShardedCache_Cost(t *testing.T) {
+	c := NewShardedCache(8, 2, WithCost(byteCost))
+	// every shard holds 4 bytes
+	assert.ErrorIs(t, c.TryPut("a", "aaaaa"), ErrTooLarge)
+	assert.ErrorIs(t, c.PutWithTTL("a", "aaaaa", 0), ErrTooLarge)
+	assert.NoError(t, c.TryPut("a", "aaaa"))
+	c.Put("b", "b")
+	assert.Equal(t, c.Len(), len(c.Keys()))
+
+	// oversized Put leaves no trace of the key
+	c.Put("a", "aaaaa")
+	assert.False(t, c.Contains("a"))
+	assert.NotContains(t, c.Keys(), "a")
+	assert.Equal(t, 1, c.Len())
+	assert.LessOrEqual(t, c.Cost(), 8)
+	assert.NoError(t, c.Validate())
+}
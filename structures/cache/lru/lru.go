@@ -1,126 +1,292 @@
 //
 // Package lru is a very simple implementation of LRU Cache structure.
 //
+// Cache is not safe for concurrent use, ShardedCache is.
+//
+// Put never fails: entry costing more than capacity is dropped silently,
+// together with old value of its key. TryPut and PutWithTTL report
+// such entry with ErrTooLarge.
+//
 package lru
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+var (
+	_ cache.Cache[string, int] = (*Cache[string, int])(nil)
+	_ cache.Cache[string, int] = (*ShardedCache[string, int])(nil)
+)
+
+// ErrTooLarge is returned when cost of entry exceeds capacity of cache
+var ErrTooLarge = errors.New("entry cost exceeds cache capacity")
+
+// EvictReason tells why entry left the cache
+type EvictReason uint8
+
+const (
+	// EvictCapacity means entry was least recently used one when cache was full
+	EvictCapacity EvictReason = iota
+	// EvictReplaced means value was overwritten by Put with the same key
+	EvictReplaced
+	// EvictDeleted means entry was removed by Delete
+	EvictDeleted
+	// EvictPurged means entry was removed by Purge
+	EvictPurged
+	// EvictExpired means entry outlived its TTL and stale window
+	EvictExpired
+	// EvictRejected means update of entry was too large to fit the cache,
+	// so old value was removed
+	EvictRejected
+)
+
+// String implements fmt.Stringer
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictReplaced:
+		return "replaced"
+	case EvictDeleted:
+		return "deleted"
+	case EvictPurged:
+		return "purged"
+	case EvictExpired:
+		return "expired"
+	case EvictRejected:
+		return "rejected"
+	}
+	return "unknown"
+}
+
+// Option configures Cache
+type Option[K comparable, V any] func(c *Cache[K, V])
+
+// WithOnEvict sets function called for every entry leaving the cache,
+// including replaced values, so resources held by them can be released
+// It is called synchronously and must not use the cache
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason EvictReason)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = fn
+	}
+}
+
 // Cache is LRU cache implementation
-type Cache struct {
-	size  int
-	queue *queue
-	data  map[string]*cacheEntry
+// It is not safe for concurrent use, even Get modifies recency queue
+// Cache runs no background janitor, expired entries are removed lazily
+// or by RemoveExpired, ShardedCache.StartJanitor calls it periodically
+type Cache[K comparable, V any] struct {
+	size    int // capacity, maximum total cost of entries
+	used    int // total cost of entries
+	cost    func(key K, value V) int
+	queue   *list.List[*entry[K, V]] // from least to most recently used
+	data    map[K]*list.Element[*entry[K, V]]
+	onEvict func(key K, value V, reason EvictReason)
+	ttl     time.Duration    // default TTL, 0 if entries never expire
+	stale   time.Duration    // how long expired entries are served by GetStale
+	now     func() time.Time // clock
 }
 
-type cacheEntry struct {
-	value interface{}
-	qItem *queueItem
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero if entry never expires
+	cost    int
 }
 
 // NewCache creates new instance of LRU cache
-// with pre-initialized data structures to `size`
-func NewCache(size int) *Cache {
-	return &Cache{
-		size:  size,
-		queue: &queue{},
-		data:  make(map[string]*cacheEntry, size),
+// Size is number of entries or their total cost if cache has cost function,
+// data structures are pre-initialized to `size` only in former case
+// Cache of size 0 or less keeps nothing
+func NewCache[K comparable, V any](size int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		size:  max(size, 0),
+		queue: list.New[*entry[K, V]](),
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.cost == nil {
+		c.data = make(map[K]*list.Element[*entry[K, V]], c.size)
+	} else {
+		c.data = make(map[K]*list.Element[*entry[K, V]])
 	}
+	return c
 }
 
 // Len returns size of cached data
-func (c *Cache) Len() int {
+// Expired entries are counted until they are removed
+func (c *Cache[K, V]) Len() int {
 	return len(c.data)
 }
 
 // Put a key-value pair into cache, it will update entry
 // if it already exists
+// Entry expires after default TTL, if cache has one
+// Entry larger than capacity is silently rejected
+// and old value of the key is removed, see TryPut
 // This operation will make entry most recently used
-func (c *Cache) Put(key string, value interface{}) {
-	if _, exists := c.data[key]; exists {
-		// update existing node and move qNode in front
-		c.data[key].value = value
-		c.queue.upfront(c.data[key].qItem)
-	} else {
-		if len(c.data) == c.size {
-			// evict least used node from cache
-			qItem := c.queue.tail
-			delete(c.data, qItem.key)
-			c.queue.evict(qItem)
-		}
-		// write new cache entry
-		c.data[key] = &cacheEntry{
-			value: value,
-			qItem: c.queue.add(key),
+func (c *Cache[K, V]) Put(key K, value V) {
+	_ = c.TryPut(key, value)
+}
+
+// TryPut is Put which returns ErrTooLarge if cost of entry exceeds capacity
+// Rejected entry removes old value of the key with EvictRejected,
+// so it is never served outdated
+func (c *Cache[K, V]) TryPut(key K, value V) error {
+	return c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL puts a key-value pair into cache which expires after ttl
+// Entry with ttl of 0 or less never expires
+// Least recently used entries are evicted until new entry fits
+// Returns ErrTooLarge if cost of entry exceeds capacity, like TryPut
+// This operation will make entry most recently used
+func (c *Cache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	cost := c.costOf(key, value)
+	if cost > c.size {
+		if el, exists := c.data[key]; exists {
+			c.remove(el, EvictRejected)
 		}
+		return ErrTooLarge
+	}
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+	if el, exists := c.data[key]; exists {
+		e := el.Value
+		old := e.value
+		c.used += cost - e.cost
+		e.value, e.expires, e.cost = value, expires, cost
+		c.queue.MoveToBack(el)
+		c.evicted(key, old, EvictReplaced)
+		c.shrink()
+		return nil
 	}
+	c.data[key] = c.queue.PushBack(&entry[K, V]{key: key, value: value, expires: expires, cost: cost})
+	c.used += cost
+	c.shrink()
+	return nil
 }
 
-// Get a value by key, return nil if value not found
+// Get a value by key, return false if value not found or expired
 // This operation will make entry most recently used
-func (c *Cache) Get(key string) interface{} {
-	if v, ok := c.data[key]; ok {
-		c.queue.upfront(v.qItem)
-		return v.value
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if el, stale := c.lookup(key); el != nil && !stale {
+		c.queue.MoveToBack(el)
+		return el.Value.value, true
 	}
-	return nil
+	var zero V
+	return zero, false
 }
 
-type queue struct {
-	tail *queueItem
-	head *queueItem
+// Peek a value by key without making entry most recently used
+// Expired value is not returned
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if el, ok := c.data[key]; ok && el.Value.fresh(c.now()) {
+		return el.Value.value, true
+	}
+	var zero V
+	return zero, false
 }
 
-type queueItem struct {
-	next *queueItem
-	prev *queueItem
-	key  string
+// Contains tests if key is in cache and not expired
+// without making entry most recently used
+func (c *Cache[K, V]) Contains(key K) bool {
+	el, ok := c.data[key]
+	return ok && el.Value.fresh(c.now())
 }
 
-// add entry in front of queue
-func (q *queue) add(key string) *queueItem {
-	newNode := &queueItem{key: key}
-	if q.head == nil {
-		// first entry
-		q.head, q.tail = newNode, newNode
-	} else {
-		// new head
-		newNode.prev = q.head
-		q.head.next = newNode
-		q.head = newNode
-	}
-	return newNode
-}
-
-// upfront moves queueItem in front of queue
-func (q *queue) upfront(n *queueItem) {
-	if n.next == nil { // already in front
-		return
-	}
-	if n.prev == nil { // this is tail
-		n.next.prev = nil
-		q.tail = n.next
-		n.next = nil
-		q.head.next = n
-		n.prev = q.head
-		q.head = n
-		return
-	}
-	// somewhere in the middle
-	n.prev.next = n.next
-	n.next.prev = n.prev
-	n.prev, n.next = q.head, nil
-	q.head.next = n
-	q.head = n
-}
-
-// evict deletes node from queue
-func (q *queue) evict(n *queueItem) {
-	if n.prev == nil {
-		if n.key == q.head.key {
-			n.prev, n.next = nil, nil
-			q.tail, q.head = nil, nil
-			return
+// Delete entry by key, return false if it was not in cache
+func (c *Cache[K, V]) Delete(key K) bool {
+	el, ok := c.data[key]
+	if !ok {
+		return false
+	}
+	c.remove(el, EvictDeleted)
+	return true
+}
+
+// Keys returns all keys from least to most recently used
+// Expired entries are included until they are removed
+func (c *Cache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.data))
+	for el := c.queue.Front(); el != nil; el = el.Next() {
+		keys = append(keys, el.Value.key)
+	}
+	return keys
+}
+
+// Purge removes all entries from cache
+func (c *Cache[K, V]) Purge() {
+	for el := c.queue.Front(); el != nil; el = c.queue.Front() {
+		c.remove(el, EvictPurged)
+	}
+}
+
+// Resize changes capacity of cache, size less than 0 is treated as 0
+// Least recently used entries are evicted until cache fits new size
+func (c *Cache[K, V]) Resize(size int) {
+	c.size = max(size, 0)
+	c.shrink()
+}
+
+// Validate cache integrity
+// Returns error should cache violate any of following rules:
+// * queue links of neighbour entries do not match, see list.List.Validate
+// * queue and map have different number of entries
+// * queued entry is not the one stored in map under its key
+// * sum of entry costs does not match total cost
+// * total cost exceeds size
+func (c *Cache[K, V]) Validate() error {
+	if err := c.queue.Validate(); err != nil {
+		return fmt.Errorf("queue: %w", err)
+	}
+	if c.queue.Len() != len(c.data) {
+		return fmt.Errorf("queue has %d entries, map has %d", c.queue.Len(), len(c.data))
+	}
+	var used int
+	for el := c.queue.Front(); el != nil; el = el.Next() {
+		if c.data[el.Value.key] != el {
+			return fmt.Errorf("queued entry with key %v is not in map", el.Value.key)
 		}
-		n.next.prev = nil
-		q.tail = n.next
-		n.prev, n.next = nil, nil
+		used += el.Value.cost
+	}
+	if used != c.used {
+		return fmt.Errorf("entries cost %d, total cost is %d", used, c.used)
+	}
+	if c.used > c.size {
+		return fmt.Errorf("cache has total cost %d, size is %d", c.used, c.size)
+	}
+	return nil
+}
+
+// shrink evicts least recently used entries until cache fits its size
+func (c *Cache[K, V]) shrink() {
+	for c.used > c.size {
+		c.remove(c.queue.Front(), EvictCapacity)
+	}
+}
+
+// remove existing entry from cache
+func (c *Cache[K, V]) remove(el *list.Element[*entry[K, V]], reason EvictReason) {
+	e := c.queue.Remove(el)
+	delete(c.data, e.key)
+	c.used -= e.cost
+	c.evicted(e.key, e.value, reason)
+}
+
+// evicted calls eviction callback if it is set
+func (c *Cache[K, V]) evicted(key K, value V, reason EvictReason) {
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
 	}
 }
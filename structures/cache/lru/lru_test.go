@@ -1,13 +1,15 @@
 package lru
 
 import (
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestLRUCache(t *testing.T) {
-	c := NewCache(5)
+	c := NewCache[string, any](5)
 	c.Put("test", 1)
 	c.Put("test2", 1)
 	c.Put("test3", 1)
@@ -21,56 +23,300 @@ func TestLRUCache(t *testing.T) {
 	assert.Equal(t, 5, c.Len())
 	assert.Equal(t, []string{"test2", "test3", "test4", "test5", "foo"}, queueList(c))
 
-	assert.Equal(t, interface{}(9), c.Get("foo"))
-	assert.Equal(t, interface{}(1), c.Get("test4"))
-	assert.Equal(t, interface{}(1), c.Get("test3"))
+	assert.Equal(t, interface{}(9), get(c, "foo"))
+	assert.Equal(t, interface{}(1), get(c, "test4"))
+	assert.Equal(t, interface{}(1), get(c, "test3"))
 	assert.Equal(t, []string{"test2", "test5", "foo", "test4", "test3"}, queueList(c))
 
-	assert.Nil(t, c.Get("buzz"))
-	assert.Nil(t, c.Get("bar"))
+	assert.Nil(t, get(c, "buzz"))
+	assert.Nil(t, get(c, "bar"))
 
 	c.Put("buzz", 2)
-	assert.Nil(t, c.Get("test2"))
+	assert.Nil(t, get(c, "test2"))
 	assert.Equal(t, []string{"test5", "foo", "test4", "test3", "buzz"}, queueList(c))
 
 	c.Put("go", "dam")
 	assert.Equal(t, []string{"foo", "test4", "test3", "buzz", "go"}, queueList(c))
 
-	assert.Equal(t, interface{}(9), c.Get("foo"))
+	assert.Equal(t, interface{}(9), get(c, "foo"))
 	assert.Equal(t, []string{"test4", "test3", "buzz", "go", "foo"}, queueList(c))
 
-	assert.Nil(t, c.queue.tail.prev)
-	assert.Nil(t, c.queue.head.next)
+	assert.NoError(t, c.Validate())
 }
 
 func TestLRUCache_SmallCache(t *testing.T) {
-	c := NewCache(1)
+	c := NewCache[string, any](1)
 	c.Put("test", 1)
 	c.Put("test2", 1)
 	assert.Equal(t, 1, c.Len())
 	assert.Equal(t, []string{"test2"}, queueList(c))
-	assert.Equal(t, interface{}(1), c.Get("test2"))
+	assert.Equal(t, interface{}(1), get(c, "test2"))
 	assert.Equal(t, []string{"test2"}, queueList(c))
 
-	assert.Nil(t, c.queue.tail.prev)
-	assert.Nil(t, c.queue.head.next)
+	assert.NoError(t, c.Validate())
 
-	c2 := NewCache(2)
+	c2 := NewCache[string, any](2)
 	c2.Put("test", 1)
 	c2.Put("test2", 1)
 	assert.Equal(t, 2, c2.Len())
 	assert.Equal(t, []string{"test", "test2"}, queueList(c2))
-	c2.Get("test")
+	get(c2, "test")
 	assert.Equal(t, []string{"test2", "test"}, queueList(c2))
 
-	assert.Nil(t, c2.queue.tail.prev)
-	assert.Nil(t, c2.queue.head.next)
+	assert.NoError(t, c2.Validate())
 }
 
-func queueList(c *Cache) []string {
+// get returns cached value or nil if there is none
+func get(c *Cache[string, any], key string) any {
+	v, _ := c.Get(key)
+	return v
+}
+
+func queueList[V any](c *Cache[string, V]) []string {
 	ret := make([]string, 0, c.size)
-	for n := c.queue.tail; n != nil; n = n.next {
-		ret = append(ret, n.key)
+	for el := c.queue.Front(); el != nil; el = el.Next() {
+		ret = append(ret, el.Value.key)
 	}
 	return ret
 }
+
+func TestLRUCache_Operations(t *testing.T) {
+	c := NewCache[int, string](3)
+	c.Put(1, "one")
+	c.Put(2, "two")
+	c.Put(3, "three")
+	assert.Equal(t, []int{1, 2, 3}, c.Keys())
+
+	// peek and contains do not promote entry
+	v, ok := c.Peek(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+	assert.True(t, c.Contains(1))
+	assert.Equal(t, []int{1, 2, 3}, c.Keys())
+	_, ok = c.Peek(4)
+	assert.False(t, ok)
+	assert.False(t, c.Contains(4))
+
+	_, ok = c.Get(4)
+	assert.False(t, ok)
+	v, ok = c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+	assert.Equal(t, []int{2, 3, 1}, c.Keys())
+
+	// delete from middle, tail and head
+	assert.True(t, c.Delete(3))
+	assert.False(t, c.Delete(3))
+	assert.Equal(t, []int{2, 1}, c.Keys())
+	assert.True(t, c.Delete(2))
+	assert.True(t, c.Delete(1))
+	assert.Empty(t, c.Keys())
+	assert.Equal(t, 0, c.Len())
+
+	for k := 1; k <= 3; k++ {
+		c.Put(k, strconv.Itoa(k))
+	}
+	c.Resize(5)
+	c.Put(4, "4")
+	c.Put(5, "5")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, c.Keys())
+	c.Resize(2)
+	assert.Equal(t, []int{4, 5}, c.Keys())
+	c.Put(6, "6")
+	assert.Equal(t, []int{5, 6}, c.Keys())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, c.Keys())
+	c.Put(7, "7")
+	assert.Equal(t, []int{7}, c.Keys())
+}
+
+func TestLRUCache_OnEvict(t *testing.T) {
+	type eviction struct {
+		key    string
+		value  int
+		reason EvictReason
+	}
+	var evicted []eviction
+	c := NewCache(2, WithOnEvict(func(key string, value int, reason EvictReason) {
+		evicted = append(evicted, eviction{key, value, reason})
+	}))
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 10)
+	c.Put("c", 3)
+	c.Get("c")
+	c.Delete("a")
+	c.Delete("a")
+	c.Put("d", 4)
+	c.Resize(1)
+	c.Purge()
+
+	assert.Equal(t, []eviction{
+		{"a", 1, EvictReplaced},
+		{"b", 2, EvictCapacity},
+		{"a", 10, EvictDeleted},
+		{"c", 3, EvictCapacity},
+		{"d", 4, EvictPurged},
+	}, evicted)
+	assert.Equal(t, "replaced", EvictReplaced.String())
+	assert.Equal(t, "rejected", EvictRejected.String())
+	assert.Equal(t, "unknown", EvictReason(100).String())
+}
+
+func TestLRUCache_ZeroSize(t *testing.T) {
+	var evicted []string
+	c := NewCache(0, WithOnEvict(func(key string, _ int, reason EvictReason) {
+		evicted = append(evicted, key)
+	}))
+	assert.ErrorIs(t, c.TryPut("a", 1), ErrTooLarge)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+	assert.False(t, c.Contains("a"))
+	assert.Empty(t, evicted)
+	assert.NoError(t, c.Validate())
+
+	c = NewCache[string, int](-1)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+	c.Resize(1)
+	c.Put("a", 1)
+	assert.Equal(t, 1, c.Len())
+	c.Resize(-1)
+	assert.Equal(t, 0, c.Len())
+	assert.NoError(t, c.Validate())
+}
+
+func TestLRUCache_Validate(t *testing.T) {
+	newCache := func() *Cache[int, int] {
+		c := NewCache[int, int](5)
+		for k := 0; k < 5; k++ {
+			c.Put(k, k)
+		}
+		assert.NoError(t, c.Validate())
+		return c
+	}
+
+	c := newCache()
+	c.queue.PushBack(&entry[int, int]{key: 5})
+	assert.Error(t, c.Validate())
+
+	c = newCache()
+	c.queue.Remove(c.data[2])
+	assert.Error(t, c.Validate())
+
+	c = newCache()
+	delete(c.data, 2)
+	assert.Error(t, c.Validate())
+
+	c = newCache()
+	c.data[2] = c.data[3]
+	assert.Error(t, c.Validate())
+
+	c = newCache()
+	c.data[2].Value.cost = 2
+	assert.Error(t, c.Validate())
+
+	c = newCache()
+	c.size = 4
+	assert.Error(t, c.Validate())
+
+	c = &Cache[int, int]{}
+	assert.Error(t, c.Validate())
+}
+
+// FuzzCache runs random operations against reference model,
+// which is slice of keys from least to most recently used
+func FuzzCache(f *testing.F) {
+	f.Add(uint8(3), []byte{0, 1, 0, 2, 0, 3, 0, 4, 1, 1, 4, 2, 0, 5})
+	f.Add(uint8(0), []byte{0, 1, 5, 2, 0, 1, 6, 0, 0, 3})
+	f.Add(uint8(2), []byte{0, 1, 0, 2, 2, 1, 3, 2, 5, 1, 0, 3, 0, 4})
+	f.Fuzz(func(t *testing.T, size uint8, ops []byte) {
+		type eviction struct {
+			key    byte
+			reason EvictReason
+		}
+		var (
+			evicted  []eviction
+			expected []eviction
+			keys     []byte
+			values   = make(map[byte]int)
+			c        = NewCache(int(size%8), WithOnEvict(func(key byte, value int, reason EvictReason) {
+				evicted = append(evicted, eviction{key, reason})
+			}))
+			capacity = int(size % 8)
+		)
+		touch := func(k byte) {
+			keys = slices.DeleteFunc(keys, func(key byte) bool { return key == k })
+			keys = append(keys, k)
+		}
+		remove := func(k byte, reason EvictReason) {
+			keys = slices.DeleteFunc(keys, func(key byte) bool { return key == k })
+			delete(values, k)
+			expected = append(expected, eviction{k, reason})
+		}
+		shrink := func() {
+			for len(keys) > capacity {
+				remove(keys[0], EvictCapacity)
+			}
+		}
+
+		for i := 0; i+1 < len(ops); i += 2 {
+			k := ops[i+1] % 16
+			switch ops[i] % 7 {
+			case 0:
+				if capacity == 0 {
+					// cache is empty and rejects every entry
+					assert.ErrorIs(t, c.TryPut(k, i), ErrTooLarge)
+					break
+				}
+				if _, ok := values[k]; ok {
+					expected = append(expected, eviction{k, EvictReplaced})
+				}
+				assert.NoError(t, c.TryPut(k, i))
+				values[k] = i
+				touch(k)
+				shrink()
+			case 1:
+				v, ok := c.Get(k)
+				expectedValue, expectedOk := values[k]
+				assert.Equal(t, expectedOk, ok)
+				assert.Equal(t, expectedValue, v)
+				if ok {
+					touch(k)
+				}
+			case 2:
+				v, ok := c.Peek(k)
+				expectedValue, expectedOk := values[k]
+				assert.Equal(t, expectedOk, ok)
+				assert.Equal(t, expectedValue, v)
+				assert.Equal(t, expectedOk, c.Contains(k))
+			case 3:
+				_, ok := values[k]
+				assert.Equal(t, ok, c.Delete(k))
+				if ok {
+					remove(k, EvictDeleted)
+				}
+			case 4:
+				capacity = int(k % 8)
+				c.Resize(capacity)
+				shrink()
+			case 5:
+				c.Purge()
+				for len(keys) > 0 {
+					remove(keys[0], EvictPurged)
+				}
+			case 6:
+				assert.Equal(t, len(keys), c.Len())
+			}
+
+			if !assert.NoError(t, c.Validate(), "operation %d", i/2) {
+				return
+			}
+			assert.Equal(t, append([]byte{}, keys...), c.Keys(), "operation %d", i/2)
+			assert.Equal(t, expected, evicted, "operation %d", i/2)
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package lru
+
+import (
+	"context"
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"time"
+)
+
+// ShardedCache is LRU cache safe for concurrent use
+// Keys are spread by hash over independently locked shards,
+// so goroutines working with different shards do not contend
+// Recency is tracked per shard, evicted entry is least recently used
+// entry of its shard, not necessarily of whole cache
+type ShardedCache[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []*cacheShard[K, V]
+}
+
+// cacheShard is Cache guarded by mutex
+// Get mutates recency queue, so reads need exclusive lock as well
+type cacheShard[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache *Cache[K, V]
+}
+
+// NewShardedCache creates concurrency-safe cache of given size split into shards
+// Size is divided between shards evenly, first size%shards shards
+// get one extra slot, so total capacity equals size
+// With cost function entry has to fit capacity of its shard
+// Shard count less than 1 is treated as 1
+// Options apply to every shard, eviction callback is called under shard lock
+func NewShardedCache[K comparable, V any](size, shards int, opts ...Option[K, V]) *ShardedCache[K, V] {
+	shards = max(shards, 1)
+	c := &ShardedCache[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*cacheShard[K, V], shards),
+	}
+	for i := range c.shards {
+		c.shards[i] = &cacheShard[K, V]{cache: NewCache(shardSize(size, shards, i), opts...)}
+	}
+	return c
+}
+
+// shardSize is size of shard i of cache with given total size
+func shardSize(size, shards, i int) int {
+	size = max(size, 0)
+	if i < size%shards {
+		return size/shards + 1
+	}
+	return size / shards
+}
+
+// shard returns shard key belongs to
+func (c *ShardedCache[K, V]) shard(key K) *cacheShard[K, V] {
+	return c.shards[maphash.Comparable(c.seed, key)%uint64(len(c.shards))]
+}
+
+// Cost returns total cost of cached entries across all shards
+func (c *ShardedCache[K, V]) Cost() int {
+	var n int
+	for _, s := range c.shards {
+		s.mu.Lock()
+		n += s.cache.Cost()
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// Len returns size of cached data across all shards
+func (c *ShardedCache[K, V]) Len() int {
+	var n int
+	for _, s := range c.shards {
+		s.mu.Lock()
+		n += s.cache.Len()
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// Put a key-value pair into cache, it will update entry
+// if it already exists
+// Entry larger than capacity of shard is silently rejected
+// and old value of the key is removed, see TryPut
+// This operation will make entry most recently used in its shard
+func (c *ShardedCache[K, V]) Put(key K, value V) {
+	_ = c.TryPut(key, value)
+}
+
+// TryPut is Put which returns ErrTooLarge if cost of entry
+// exceeds capacity of its shard
+func (c *ShardedCache[K, V]) TryPut(key K, value V) error {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.TryPut(key, value)
+}
+
+// PutWithTTL puts a key-value pair into cache which expires after ttl
+// Returns ErrTooLarge if cost of entry exceeds capacity of its shard
+// This operation will make entry most recently used in its shard
+func (c *ShardedCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.PutWithTTL(key, value, ttl)
+}
+
+// Get a value by key, return false if value not found or expired
+// This operation will make entry most recently used in its shard
+func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+// GetStale a value by key, expired value is returned as well
+// while it is within stale window, see Cache.GetStale
+// This operation will make entry most recently used in its shard
+func (c *ShardedCache[K, V]) GetStale(key K) (value V, stale, ok bool) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.GetStale(key)
+}
+
+// Peek a value by key without making entry most recently used
+func (c *ShardedCache[K, V]) Peek(key K) (V, bool) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Peek(key)
+}
+
+// Contains tests if key is in cache without making entry most recently used
+func (c *ShardedCache[K, V]) Contains(key K) bool {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Contains(key)
+}
+
+// Delete entry by key, return false if it was not in cache
+func (c *ShardedCache[K, V]) Delete(key K) bool {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Delete(key)
+}
+
+// Keys returns all keys shard by shard,
+// keys of every shard go from least to most recently used
+func (c *ShardedCache[K, V]) Keys() []K {
+	var keys []K
+	for _, s := range c.shards {
+		s.mu.Lock()
+		keys = append(keys, s.cache.Keys()...)
+		s.mu.Unlock()
+	}
+	return keys
+}
+
+// Purge removes all entries from cache
+func (c *ShardedCache[K, V]) Purge() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		s.cache.Purge()
+		s.mu.Unlock()
+	}
+}
+
+// Resize changes total capacity of cache, it is divided between shards
+// same way as in NewShardedCache
+func (c *ShardedCache[K, V]) Resize(size int) {
+	for i, s := range c.shards {
+		s.mu.Lock()
+		s.cache.Resize(shardSize(size, len(c.shards), i))
+		s.mu.Unlock()
+	}
+}
+
+// RemoveExpired removes entries which outlived their TTL and stale window
+// Shards are locked one at a time
+// Returns number of removed entries
+func (c *ShardedCache[K, V]) RemoveExpired() int {
+	var removed int
+	for _, s := range c.shards {
+		s.mu.Lock()
+		removed += s.cache.RemoveExpired()
+		s.mu.Unlock()
+	}
+	return removed
+}
+
+// StartJanitor starts goroutine which calls RemoveExpired every interval
+// Goroutine stops when ctx is done, returned channel is closed after that
+// Interval of 0 or less starts nothing and returned channel is already closed
+func (c *ShardedCache[K, V]) StartJanitor(ctx context.Context, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	if interval <= 0 {
+		close(done)
+		return done
+	}
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.RemoveExpired()
+			}
+		}
+	}()
+	return done
+}
+
+// Validate integrity of every shard
+// Returns error of first shard which violates rules of Cache.Validate
+// or has key which does not belong to it
+func (c *ShardedCache[K, V]) Validate() error {
+	for i, s := range c.shards {
+		s.mu.Lock()
+		err := s.cache.Validate()
+		if err == nil {
+			for key := range s.cache.data {
+				if c.shard(key) != s {
+					err = fmt.Errorf("key %v is in wrong shard", key)
+					break
+				}
+			}
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
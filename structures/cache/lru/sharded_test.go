@@ -0,0 +1,156 @@
+package lru
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedCache(t *testing.T) {
+	c := NewShardedCache[string, int](16, 4)
+	assert.Len(t, c.shards, 4)
+	for _, s := range c.shards {
+		assert.Equal(t, 4, s.cache.size)
+	}
+
+	for i := 0; i < 100; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	// every shard is full, its least recently used entries are evicted
+	assert.Equal(t, 16, c.Len())
+	assert.Len(t, c.Keys(), 16)
+	for _, s := range c.shards {
+		assert.Equal(t, 4, s.cache.Len())
+	}
+	var found int
+	for i := 0; i < 100; i++ {
+		if v, ok := c.Get(strconv.Itoa(i)); ok {
+			assert.Equal(t, i, v)
+			found++
+		}
+	}
+	assert.Equal(t, 16, found)
+	v, ok := c.Get("99")
+	assert.True(t, ok)
+	assert.Equal(t, 99, v)
+
+	c.Put("99", -1)
+	v, _ = c.Peek("99")
+	assert.Equal(t, -1, v)
+	assert.Equal(t, 16, c.Len())
+
+	assert.True(t, c.Contains("99"))
+	assert.True(t, c.Delete("99"))
+	assert.False(t, c.Delete("99"))
+	assert.False(t, c.Contains("99"))
+	assert.Equal(t, 15, c.Len())
+
+	c.Resize(8)
+	for _, s := range c.shards {
+		assert.LessOrEqual(t, s.cache.Len(), 2)
+	}
+	assert.NoError(t, c.Validate())
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+
+	// move entry to another shard
+	c.Put("1", 1)
+	s := c.shard("1")
+	other := c.shards[0]
+	if other == s {
+		other = c.shards[1]
+	}
+	s.cache.Delete("1")
+	other.cache.Put("1", 1)
+	assert.Error(t, c.Validate())
+}
+
+func TestShardedCache_Size(t *testing.T) {
+	// remainder of size goes to first shards
+	c := NewShardedCache[string, int](10, 4)
+	var sizes []int
+	for _, s := range c.shards {
+		sizes = append(sizes, s.cache.size)
+	}
+	assert.Equal(t, []int{3, 3, 2, 2}, sizes)
+
+	// total capacity equals size even with more shards than slots
+	c = NewShardedCache[string, int](5, 16)
+	var total int
+	for _, s := range c.shards {
+		total += s.cache.size
+	}
+	assert.Equal(t, 5, total)
+	for i := 0; i < 100; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	assert.LessOrEqual(t, c.Len(), 5)
+
+	c.Resize(18)
+	sizes = nil
+	for _, s := range c.shards {
+		sizes = append(sizes, s.cache.size)
+	}
+	assert.Equal(t, []int{2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, sizes)
+
+	c = NewShardedCache[string, int](3, 0)
+	assert.Len(t, c.shards, 1)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Put("d", 4)
+	assert.Equal(t, 3, c.Len())
+	assert.False(t, c.Contains("a"))
+	assert.Equal(t, []string{"b", "c", "d"}, c.Keys())
+}
+
+// TestShardedCache_Parallel is meant to be run with race detector
+func TestShardedCache_Parallel(t *testing.T) {
+	const (
+		workers = 8
+		ops     = 10000
+		keys    = 256
+	)
+	var (
+		evicted = make([]int, EvictRejected+1) // callbacks of different shards run concurrently
+		mu      sync.Mutex
+	)
+	c := NewShardedCache(keys/2, 8, WithOnEvict(func(key, value string, reason EvictReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted[reason]++
+	}))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				key := strconv.Itoa((w*ops + i*7) % keys)
+				switch i % 5 {
+				case 0:
+					c.Put(key, key)
+				case 1:
+					c.Delete(key)
+				case 2:
+					if v, ok := c.Peek(key); ok {
+						assert.Equal(t, key, v)
+					}
+				default:
+					if v, ok := c.Get(key); ok {
+						assert.Equal(t, key, v)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, c.Len(), keys/2)
+	assert.Len(t, c.Keys(), c.Len())
+	assert.NoError(t, c.Validate())
+	assert.Greater(t, evicted[EvictDeleted], 0)
+}
@@ -0,0 +1,88 @@
+package lru
+
+import (
+	"time"
+
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+// WithTTL sets default TTL of entries put into cache, 0 disables expiry
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.ttl = max(ttl, 0)
+	}
+}
+
+// WithStaleWindow keeps expired entries for duration after their expiry,
+// so GetStale can serve them while value is being revalidated
+func WithStaleWindow[K comparable, V any](window time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.stale = max(window, 0)
+	}
+}
+
+// WithClock replaces time.Now as source of current time, meant for tests
+func WithClock[K comparable, V any](now func() time.Time) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.now = now
+	}
+}
+
+// fresh tests if entry is not expired at given time
+func (e *entry[K, V]) fresh(now time.Time) bool {
+	return e.expires.IsZero() || now.Before(e.expires)
+}
+
+// dead tests if entry is expired and outlived stale window at given time
+func (e *entry[K, V]) dead(now time.Time, stale time.Duration) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires.Add(stale))
+}
+
+// lookup returns entry by key and tells if it is expired
+// Entry which outlived stale window is removed and nil is returned
+func (c *Cache[K, V]) lookup(key K) (el *list.Element[*entry[K, V]], stale bool) {
+	el, ok := c.data[key]
+	if !ok {
+		return nil, false
+	}
+	now := c.now()
+	if el.Value.dead(now, c.stale) {
+		c.remove(el, EvictExpired)
+		return nil, false
+	}
+	return el, !el.Value.fresh(now)
+}
+
+// GetStale a value by key, expired value is returned as well
+// while it is within stale window, which allows to serve it
+// and revalidate it in background (stale-while-revalidate)
+// Stale is true if value is expired, ok is false if value not found
+// This operation will make entry most recently used
+func (c *Cache[K, V]) GetStale(key K) (value V, stale, ok bool) {
+	el, stale := c.lookup(key)
+	if el == nil {
+		return value, false, false
+	}
+	c.queue.MoveToBack(el)
+	return el.Value.value, stale, true
+}
+
+// RemoveExpired removes entries which outlived their TTL and stale window
+// Expired entries are otherwise removed lazily, when they are looked up
+// or when they become least recently used
+// Returns number of removed entries
+func (c *Cache[K, V]) RemoveExpired() int {
+	var (
+		removed int
+		now     = c.now()
+	)
+	for el := c.queue.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.dead(now, c.stale) {
+			c.remove(el, EvictExpired)
+			removed++
+		}
+		el = next
+	}
+	return removed
+}
@@ -0,0 +1,168 @@
+package lru
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is manually advanced clock safe for concurrent use
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestLRUCache_TTL(t *testing.T) {
+	var (
+		clock   = newTestClock()
+		evicted []string
+	)
+	c := NewCache(5,
+		WithTTL[string, int](time.Minute),
+		WithClock[string, int](clock.Now),
+		WithOnEvict(func(key string, _ int, reason EvictReason) {
+			evicted = append(evicted, key+" "+reason.String())
+		}),
+	)
+	c.Put("default", 1)
+	c.PutWithTTL("short", 2, time.Second)
+	c.PutWithTTL("forever", 3, 0)
+
+	clock.Advance(time.Second)
+	_, ok := c.Get("short")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"short expired"}, evicted)
+	assert.Equal(t, 2, c.Len())
+
+	v, ok := c.Get("default")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// put resets expiry
+	clock.Advance(30 * time.Second)
+	c.Put("default", 10)
+	clock.Advance(59 * time.Second)
+	assert.True(t, c.Contains("default"))
+	clock.Advance(time.Second)
+
+	// peek and contains do not remove expired entry
+	assert.False(t, c.Contains("default"))
+	_, ok = c.Peek("default")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"forever", "default"}, c.Keys())
+
+	clock.Advance(24 * time.Hour)
+	v, ok = c.Get("forever")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	assert.Equal(t, 1, c.RemoveExpired())
+	assert.Equal(t, []string{"forever"}, c.Keys())
+	assert.Equal(t, []string{"short expired", "default replaced", "default expired"}, evicted)
+	assert.NoError(t, c.Validate())
+}
+
+func TestLRUCache_GetStale(t *testing.T) {
+	clock := newTestClock()
+	c := NewCache(5,
+		WithTTL[string, int](time.Minute),
+		WithStaleWindow[string, int](time.Minute),
+		WithClock[string, int](clock.Now),
+	)
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	v, stale, ok := c.GetStale("a")
+	assert.Equal(t, 1, v)
+	assert.False(t, stale)
+	assert.True(t, ok)
+
+	clock.Advance(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.RemoveExpired())
+
+	// stale value is served and promoted
+	v, stale, ok = c.GetStale("a")
+	assert.Equal(t, 1, v)
+	assert.True(t, stale)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"b", "a"}, c.Keys())
+
+	// revalidated value is fresh
+	c.Put("a", 10)
+	v, stale, _ = c.GetStale("a")
+	assert.Equal(t, 10, v)
+	assert.False(t, stale)
+
+	clock.Advance(time.Minute)
+	_, _, ok = c.GetStale("b")
+	assert.False(t, ok)
+	assert.False(t, c.Contains("a"))
+	assert.Equal(t, []string{"a"}, c.Keys())
+
+	_, _, ok = c.GetStale("c")
+	assert.False(t, ok)
+}
+
+func TestShardedCache_Janitor(t *testing.T) {
+	clock := newTestClock()
+	c := NewShardedCache(100, 4,
+		WithTTL[string, int](time.Minute),
+		WithClock[string, int](clock.Now),
+	)
+	for i := 0; i < 50; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	c.PutWithTTL("forever", -1, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := c.StartJanitor(ctx, time.Millisecond)
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		return c.Len() == 1
+	}, time.Second, time.Millisecond)
+	assert.True(t, c.Contains("forever"))
+
+	_, stale, ok := c.GetStale("forever")
+	assert.False(t, stale)
+	assert.True(t, ok)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not stop")
+	}
+	assert.NoError(t, c.Validate())
+
+	// janitor without interval is not started
+	for _, interval := range []time.Duration{0, -time.Second} {
+		select {
+		case <-c.StartJanitor(context.Background(), interval):
+		default:
+			t.Fatalf("janitor with interval %v was started", interval)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package tinylfu
+
+import (
+	"math/bits"
+)
+
+const (
+	// sketchDepth is number of rows of count-min sketch
+	sketchDepth = 4
+	// maxCount is maximum value of 4-bit counter
+	maxCount = 15
+	// resetMultiplier is number of increments per cache entry
+	// after which all counters are halved
+	resetMultiplier = 10
+)
+
+// sketchSeeds are mixed into hash to get independent index for every row
+var sketchSeeds = [sketchDepth]uint64{
+	0xc3a5c85c97cb3127, 0xb492b66fbe98f273, 0x9ae16a3b2f90404f, 0xcbf29ce484222325,
+}
+
+// sketch is count-min sketch estimating access frequency of keys
+// Counters saturate at maxCount, and are halved periodically,
+// so old popularity fades out (aging)
+// https://en.wikipedia.org/wiki/Count%E2%80%93min_sketch
+type sketch struct {
+	rows      [sketchDepth][]uint8
+	mask      uint64
+	additions int
+	resetAt   int
+}
+
+// newSketch creates sketch for cache of given size
+// Width of rows is size rounded up to power of two
+func newSketch(size int) *sketch {
+	width := 1 << bits.Len(uint(max(size, 16)-1))
+	s := &sketch{
+		mask:    uint64(width - 1),
+		resetAt: resetMultiplier * max(size, 1),
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// index of counter for key hash in row i
+func (s *sketch) index(h uint64, i int) uint64 {
+	// splitmix64 finalizer
+	h ^= sketchSeeds[i]
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	return (h ^ (h >> 31)) & s.mask
+}
+
+// increment counters of key hash
+func (s *sketch) increment(h uint64) {
+	for i := range s.rows {
+		if j := s.index(h, i); s.rows[i][j] < maxCount {
+			s.rows[i][j]++
+		}
+	}
+	if s.additions++; s.additions == s.resetAt {
+		s.reset()
+	}
+}
+
+// estimate frequency of key hash, which is minimum of its counters
+func (s *sketch) estimate(h uint64) int {
+	count := maxCount
+	for i := range s.rows {
+		count = min(count, int(s.rows[i][s.index(h, i)]))
+	}
+	return count
+}
+
+// reset halves every counter
+func (s *sketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// clear sets every counter to zero
+func (s *sketch) clear() {
+	for i := range s.rows {
+		clear(s.rows[i])
+	}
+	s.additions = 0
+}
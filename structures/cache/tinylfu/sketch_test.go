@@ -0,0 +1,43 @@
+package tinylfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch(t *testing.T) {
+	s := newSketch(100)
+	assert.Len(t, s.rows[0], 128)
+	assert.Equal(t, 1000, s.resetAt)
+	assert.Len(t, newSketch(0).rows[0], 16)
+
+	for h := uint64(0); h < 100; h++ {
+		for j := uint64(0); j <= h%8; j++ {
+			s.increment(h)
+		}
+	}
+	// count-min sketch never underestimates
+	for h := uint64(0); h < 100; h++ {
+		assert.GreaterOrEqual(t, s.estimate(h), int(h%8)+1)
+	}
+
+	// counters saturate
+	for j := 0; j < 100; j++ {
+		s.increment(1000)
+	}
+	assert.Equal(t, maxCount, s.estimate(1000))
+
+	// 1000th increment halves counters
+	assert.Equal(t, 542, s.additions)
+	for s.additions < s.resetAt-1 {
+		s.increment(2000)
+	}
+	s.increment(3000)
+	assert.Equal(t, 500, s.additions)
+	assert.Equal(t, maxCount/2, s.estimate(1000))
+
+	s.clear()
+	assert.Equal(t, 0, s.estimate(1000))
+	assert.Equal(t, 0, s.additions)
+}
@@ -0,0 +1,192 @@
+//
+// Package tinylfu implements W-TinyLFU Cache.
+//
+// New entries go to small LRU window. Entry evicted from window is admitted
+// to main segmented LRU only if it was accessed more often than entry main
+// cache would evict instead. Access frequency is estimated by count-min sketch,
+// so frequencies of keys which are not in cache are remembered as well.
+//
+// Main cache is split into probation and protected segments, entry accessed
+// on probation is promoted to protected segment.
+//
+// https://arxiv.org/abs/1512.00727
+//
+package tinylfu
+
+import (
+	"hash/maphash"
+
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+var _ cache.Cache[string, int] = (*Cache[string, int])(nil)
+
+const (
+	// WindowRatio is share of cache size given to LRU window
+	WindowRatio = 0.01
+	// ProtectedRatio is share of main cache given to protected segment
+	ProtectedRatio = 0.8
+)
+
+// Cache is W-TinyLFU cache implementation
+// It is not safe for concurrent use
+type Cache[K comparable, V any] struct {
+	size         int
+	windowCap    int
+	mainCap      int
+	protectedCap int
+	hash         func(key K) uint64 // hash of key counted by sketch
+	sketch       *sketch
+	window       *list.List[*entry[K, V]]
+	probation    *list.List[*entry[K, V]]
+	protected    *list.List[*entry[K, V]]
+	data         map[K]*list.Element[*entry[K, V]]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64
+}
+
+// NewCache creates new instance of W-TinyLFU cache of given size
+// Cache of size 0 or less keeps nothing
+// Keys are hashed with random seed, so colliding keys differ between caches
+func NewCache[K comparable, V any](size int) *Cache[K, V] {
+	seed := maphash.MakeSeed()
+	return newCache[K, V](size, func(key K) uint64 {
+		return maphash.Comparable(seed, key)
+	})
+}
+
+// newCache creates cache hashing keys with given function,
+// fixed hash makes frequency estimates and hit ratio reproducible in tests
+func newCache[K comparable, V any](size int, hash func(key K) uint64) *Cache[K, V] {
+	size = max(size, 0)
+	windowCap := min(max(int(float64(size)*WindowRatio), 1), size)
+	mainCap := size - windowCap
+	return &Cache[K, V]{
+		size:         size,
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: int(float64(mainCap) * ProtectedRatio),
+		hash:         hash,
+		sketch:       newSketch(size),
+		window:       list.New[*entry[K, V]](),
+		probation:    list.New[*entry[K, V]](),
+		protected:    list.New[*entry[K, V]](),
+		data:         make(map[K]*list.Element[*entry[K, V]], size),
+	}
+}
+
+// Len returns size of cached data
+func (c *Cache[K, V]) Len() int {
+	return len(c.data)
+}
+
+// Put a key-value pair into cache, it will update entry if it already exists
+// New entry goes to window, which may push its oldest entry to main cache
+// or out of cache
+func (c *Cache[K, V]) Put(key K, value V) {
+	h := c.hash(key)
+	c.sketch.increment(h)
+	if e, ok := c.data[key]; ok {
+		e.Value.value = value
+		c.access(e)
+		return
+	}
+	if c.size == 0 {
+		return
+	}
+	c.data[key] = c.window.PushBack(&entry[K, V]{key: key, value: value, hash: h})
+	if c.window.Len() > c.windowCap {
+		c.admit(c.window.Remove(c.window.Front()))
+	}
+}
+
+// Get a value by key, return false if value not found
+// Access is recorded by frequency sketch even if value is not found
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.sketch.increment(c.hash(key))
+	if e, ok := c.data[key]; ok {
+		c.access(e)
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek a value by key without recording access
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.data[key]; ok {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains tests if key is in cache without recording access
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.data[key]
+	return ok
+}
+
+// Delete entry by key, return false if it was not in cache
+func (c *Cache[K, V]) Delete(key K) bool {
+	e, ok := c.data[key]
+	if !ok {
+		return false
+	}
+	delete(c.data, key)
+	e.List().Remove(e)
+	return true
+}
+
+// Purge removes all entries from cache and resets frequency sketch
+func (c *Cache[K, V]) Purge() {
+	c.window.Clear()
+	c.probation.Clear()
+	c.protected.Clear()
+	clear(c.data)
+	c.sketch.clear()
+}
+
+// access moves entry within its segment or promotes it from probation
+func (c *Cache[K, V]) access(e *list.Element[*entry[K, V]]) {
+	switch e.List() {
+	case c.window:
+		c.window.MoveToBack(e)
+	case c.protected:
+		c.protected.MoveToBack(e)
+	case c.probation:
+		c.probation.Remove(e)
+		c.data[e.Value.key] = c.protected.PushBack(e.Value)
+		if c.protected.Len() > c.protectedCap {
+			// demote least recently used protected entry
+			demoted := c.protected.Remove(c.protected.Front())
+			c.data[demoted.key] = c.probation.PushBack(demoted)
+		}
+	}
+}
+
+// admit candidate evicted from window to probation segment
+// If main cache is full, candidate competes with its victim
+// and the one with lower estimated frequency leaves the cache
+func (c *Cache[K, V]) admit(candidate *entry[K, V]) {
+	if c.probation.Len()+c.protected.Len() < c.mainCap {
+		c.data[candidate.key] = c.probation.PushBack(candidate)
+		return
+	}
+	victim := c.probation.Front()
+	if victim == nil {
+		victim = c.protected.Front()
+	}
+	if victim == nil || c.sketch.estimate(candidate.hash) <= c.sketch.estimate(victim.Value.hash) {
+		delete(c.data, candidate.key)
+		return
+	}
+	delete(c.data, victim.Value.key)
+	victim.List().Remove(victim)
+	c.data[candidate.key] = c.probation.PushBack(candidate)
+}
@@ -0,0 +1,162 @@
+package tinylfu
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+	"github.com/hasansino/gobasics/structures/cache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func keys(t *testing.T, l *list.List[*entry[string, int]]) []string {
+	assert.NoError(t, l.Validate())
+	var ret []string
+	for e := l.Front(); e != nil; e = e.Next() {
+		ret = append(ret, e.Value.key)
+	}
+	return ret
+}
+
+// hashString is fixed FNV-1a hash, which keeps sketch estimates the same in every run
+func hashString(k string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(k))
+	return h.Sum64()
+}
+
+// hashInt is fixed hash of integer keys, sketch mixes it further
+func hashInt(k int) uint64 {
+	return uint64(k)
+}
+
+// newTestCache creates cache of size 10 with window of 1 entry,
+// probation and protected segments of 9 and 7 entries
+// Sketch is large enough to avoid collisions of test keys
+func newTestCache() *Cache[string, int] {
+	c := newCache[string, int](10, hashString)
+	c.sketch = newSketch(1 << 12)
+	return c
+}
+
+func TestTinyLFUCache(t *testing.T) {
+	c := newTestCache()
+	assert.Equal(t, 1, c.windowCap)
+	assert.Equal(t, 9, c.mainCap)
+	assert.Equal(t, 7, c.protectedCap)
+
+	for i := 0; i < 10; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 10, c.Len())
+	assert.Equal(t, []string{"9"}, keys(t, c.window))
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8"}, keys(t, c.probation))
+
+	// accessed entry is promoted
+	v, ok := c.Get("0")
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	assert.Equal(t, []string{"0"}, keys(t, c.protected))
+
+	// candidate is not more frequent than victim and is not admitted
+	c.Put("a", 1)
+	assert.False(t, c.Contains("9"))
+	assert.Equal(t, []string{"a"}, keys(t, c.window))
+
+	// misses are counted, frequent key wins over victim
+	c.Get("b")
+	c.Get("b")
+	c.Put("b", 2)
+	assert.False(t, c.Contains("a"))
+	c.Put("c", 3)
+	assert.True(t, c.Contains("b"))
+	assert.False(t, c.Contains("1"))
+	assert.Equal(t, []string{"2", "3", "4", "5", "6", "7", "8", "b"}, keys(t, c.probation))
+	assert.Equal(t, 10, c.Len())
+
+	// peek does not promote
+	_, ok = c.Peek("2")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"0"}, keys(t, c.protected))
+
+	// protected segment over its share demotes its oldest entry
+	for _, k := range []string{"2", "3", "4", "5", "6", "7", "8"} {
+		c.Get(k)
+	}
+	assert.Equal(t, []string{"2", "3", "4", "5", "6", "7", "8"}, keys(t, c.protected))
+	assert.Equal(t, []string{"b", "0"}, keys(t, c.probation))
+
+	assert.True(t, c.Delete("c"))
+	assert.True(t, c.Delete("0"))
+	assert.False(t, c.Delete("0"))
+	assert.Equal(t, 8, c.Len())
+	c.Put("d", 4)
+	c.Put("e", 5)
+	assert.Equal(t, []string{"b", "d"}, keys(t, c.probation))
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.sketch.estimate(hashString("b")))
+}
+
+func TestTinyLFUCache_SmallCache(t *testing.T) {
+	c := NewCache[string, int](0)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+
+	// window takes whole cache
+	c = NewCache[string, int](1)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	assert.Equal(t, 1, c.Len())
+	assert.True(t, c.Contains("b"))
+}
+
+// hitRatio replays trace on cache, every miss is followed by Put
+func hitRatio(c interface {
+	Get(k int) (int, bool)
+	Put(k, v int)
+}, trace []int) float64 {
+	var hits int
+	for _, k := range trace {
+		if _, ok := c.Get(k); ok {
+			hits++
+		} else {
+			c.Put(k, k)
+		}
+	}
+	return float64(hits) / float64(len(trace))
+}
+
+// TestTinyLFUCache_HitRatio replays scan and loop traces of cache_test
+// with fixed hash, so ratios are the same in every run
+func TestTinyLFUCache_HitRatio(t *testing.T) {
+	const size = 1000
+
+	// zipf interleaved with sequential reads of keys never seen again
+	var (
+		zipf = rand.NewZipf(rand.New(rand.NewPCG(1, 2)), 1.1, 1, 1<<16)
+		scan = make([]int, 1<<17)
+		next = 1 << 20
+	)
+	for i := range scan {
+		if i%5000 < 4000 {
+			scan[i] = int(zipf.Uint64())
+		} else {
+			scan[i] = next
+			next++
+		}
+	}
+	assert.Greater(t,
+		hitRatio(newCache[int, int](size, hashInt), scan),
+		hitRatio(lru.NewCache[int, int](size), scan))
+
+	// cyclic access to slightly more keys than cache holds
+	loop := make([]int, 1<<17)
+	for i := range loop {
+		loop[i] = i % (size + size/4)
+	}
+	assert.Greater(t, hitRatio(newCache[int, int](size, hashInt), loop), 0.5)
+}
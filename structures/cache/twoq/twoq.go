@@ -0,0 +1,155 @@
+//
+// Package twoq implements 2Q Cache.
+//
+// New entries go to FIFO queue A1in. Keys evicted from it are remembered
+// in ghost queue A1out without values, and only entry put again while its key
+// is remembered is considered hot and goes to LRU queue Am. This way one-time
+// accesses, like scans, do not flush frequently used entries out of cache.
+//
+// https://en.wikipedia.org/wiki/Cache_replacement_policies#2Q
+// https://www.vldb.org/conf/1994/P439.PDF
+//
+package twoq
+
+import (
+	"github.com/hasansino/gobasics/structures/cache"
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+)
+
+var _ cache.Cache[string, int] = (*Cache[string, int])(nil)
+
+const (
+	// InRatio is share of cache size given to A1in queue
+	InRatio = 0.25
+	// GhostRatio is number of keys remembered by A1out relative to cache size
+	GhostRatio = 0.5
+)
+
+// Cache is 2Q cache implementation
+// It is not safe for concurrent use
+type Cache[K comparable, V any] struct {
+	size     int
+	inSize   int
+	ghostCap int
+	in       *list.List[*entry[K, V]] // A1in, FIFO of entries seen once
+	hot      *list.List[*entry[K, V]] // Am, LRU of entries seen more than once
+	ghost    *list.List[K]            // A1out, FIFO of keys evicted from A1in
+	data     map[K]*list.Element[*entry[K, V]]
+	ghosts   map[K]*list.Element[K]
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewCache creates new instance of 2Q cache of given size
+// Cache of size 0 or less keeps nothing
+func NewCache[K comparable, V any](size int) *Cache[K, V] {
+	size = max(size, 0)
+	return &Cache[K, V]{
+		size:     size,
+		inSize:   max(int(float64(size)*InRatio), 1),
+		ghostCap: max(int(float64(size)*GhostRatio), 1),
+		in:       list.New[*entry[K, V]](),
+		hot:      list.New[*entry[K, V]](),
+		ghost:    list.New[K](),
+		data:     make(map[K]*list.Element[*entry[K, V]], size),
+		ghosts:   make(map[K]*list.Element[K]),
+	}
+}
+
+// Len returns size of cached data
+func (c *Cache[K, V]) Len() int {
+	return len(c.data)
+}
+
+// Put a key-value pair into cache, it will update entry if it already exists
+// Entry goes to Am if its key was recently evicted from A1in, otherwise to A1in
+func (c *Cache[K, V]) Put(key K, value V) {
+	if e, ok := c.data[key]; ok {
+		e.Value.value = value
+		c.hot.MoveToBack(e) // entries of A1in are not moved
+		return
+	}
+	if c.size == 0 {
+		return
+	}
+	// key is taken from A1out before reclaim could forget it
+	g, hot := c.ghosts[key]
+	if hot {
+		c.ghost.Remove(g)
+		delete(c.ghosts, key)
+	}
+	if len(c.data) == c.size {
+		c.reclaim()
+	}
+	if hot {
+		c.data[key] = c.hot.PushBack(&entry[K, V]{key: key, value: value})
+		return
+	}
+	c.data[key] = c.in.PushBack(&entry[K, V]{key: key, value: value})
+}
+
+// Get a value by key, return false if value not found
+// Entry of Am becomes most recently used, A1in is not reordered
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if e, ok := c.data[key]; ok {
+		c.hot.MoveToBack(e)
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek a value by key without making entry most recently used
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.data[key]; ok {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains tests if key is in cache without making entry most recently used
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.data[key]
+	return ok
+}
+
+// Delete entry by key, return false if it was not in cache
+func (c *Cache[K, V]) Delete(key K) bool {
+	e, ok := c.data[key]
+	if !ok {
+		return false
+	}
+	delete(c.data, key)
+	e.List().Remove(e)
+	return true
+}
+
+// Purge removes all entries and remembered keys from cache
+func (c *Cache[K, V]) Purge() {
+	c.in.Clear()
+	c.hot.Clear()
+	c.ghost.Clear()
+	clear(c.data)
+	clear(c.ghosts)
+}
+
+// reclaim space for one entry
+// Oldest entry of A1in is evicted if A1in is over its share, its key is
+// remembered in A1out, otherwise least recently used entry of Am is evicted
+func (c *Cache[K, V]) reclaim() {
+	if c.in.Len() > c.inSize || c.hot.Len() == 0 {
+		e := c.in.Remove(c.in.Front())
+		delete(c.data, e.key)
+		if c.ghost.Len() == c.ghostCap {
+			delete(c.ghosts, c.ghost.Remove(c.ghost.Front()))
+		}
+		c.ghosts[e.key] = c.ghost.PushBack(e.key)
+		return
+	}
+	e := c.hot.Remove(c.hot.Front())
+	delete(c.data, e.key)
+}
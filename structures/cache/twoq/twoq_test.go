@@ -0,0 +1,120 @@
+package twoq
+
+import (
+	"testing"
+
+	"github.com/hasansino/gobasics/structures/cache/internal/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func keys[T any](t *testing.T, l *list.List[T], key func(T) string) []string {
+	assert.NoError(t, l.Validate())
+	var ret []string
+	for e := l.Front(); e != nil; e = e.Next() {
+		ret = append(ret, key(e.Value))
+	}
+	return ret
+}
+
+// queues returns keys of A1in, Am and A1out
+func queues(t *testing.T, c *Cache[string, int]) (in, hot, ghost []string) {
+	entryKey := func(e *entry[string, int]) string { return e.key }
+	return keys(t, c.in, entryKey), keys(t, c.hot, entryKey), keys(t, c.ghost, func(k string) string { return k })
+}
+
+func TestTwoQueueCache(t *testing.T) {
+	// A1in holds 2 entries, A1out remembers 4 keys
+	c := NewCache[string, int](8)
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		c.Put(k, 1)
+	}
+	assert.Equal(t, 8, c.Len())
+
+	// A1in is over its share, oldest entries go to A1out
+	c.Put("i", 1)
+	c.Put("j", 1)
+	in, hot, ghost := queues(t, c)
+	assert.Equal(t, []string{"c", "d", "e", "f", "g", "h", "i", "j"}, in)
+	assert.Empty(t, hot)
+	assert.Equal(t, []string{"a", "b"}, ghost)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	// remembered key is hot
+	c.Put("a", 2)
+	in, hot, ghost = queues(t, c)
+	assert.Equal(t, []string{"d", "e", "f", "g", "h", "i", "j"}, in)
+	assert.Equal(t, []string{"a"}, hot)
+	assert.Equal(t, []string{"b", "c"}, ghost)
+
+	// get does not reorder A1in
+	v, ok := c.Get("d")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	in, _, _ = queues(t, c)
+	assert.Equal(t, "d", in[0])
+
+	// scan does not evict hot entry
+	for _, k := range []string{"k", "l", "m", "n", "o", "p"} {
+		c.Put(k, 1)
+	}
+	in, hot, ghost = queues(t, c)
+	assert.Equal(t, []string{"j", "k", "l", "m", "n", "o", "p"}, in)
+	assert.Equal(t, []string{"a"}, hot)
+	assert.Equal(t, []string{"f", "g", "h", "i"}, ghost)
+
+	c.Put("h", 3)
+	c.Put("i", 4)
+	c.Put("i", 5)
+	c.Get("h")
+	in, hot, _ = queues(t, c)
+	assert.Equal(t, []string{"l", "m", "n", "o", "p"}, in)
+	assert.Equal(t, []string{"a", "i", "h"}, hot)
+
+	// A1in fits its share, Am is reclaimed
+	for _, k := range []string{"l", "m", "n", "o", "p"} {
+		c.Delete(k)
+	}
+	_, _, ghost = queues(t, c)
+	assert.Equal(t, []string{"f", "g", "j", "k"}, ghost)
+	for _, k := range []string{"g", "j", "k", "q", "r", "s"} {
+		c.Put(k, 1)
+	}
+	in, hot, _ = queues(t, c)
+	assert.Equal(t, []string{"q", "r", "s"}, in)
+	assert.Equal(t, []string{"i", "h", "g", "j", "k"}, hot)
+
+	v, ok = c.Peek("i")
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	assert.True(t, c.Contains("h"))
+	assert.False(t, c.Delete("a"))
+
+	c.Purge()
+	in, hot, ghost = queues(t, c)
+	assert.Equal(t, 0, c.Len())
+	assert.Empty(t, in)
+	assert.Empty(t, hot)
+	assert.Empty(t, ghost)
+	assert.Empty(t, c.ghosts)
+}
+
+func TestTwoQueueCache_SmallCache(t *testing.T) {
+	c := NewCache[string, int](0)
+	c.Put("a", 1)
+	assert.Equal(t, 0, c.Len())
+
+	c = NewCache[string, int](1)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 3)
+	in, hot, ghost := queues(t, c)
+	assert.Empty(t, in)
+	assert.Equal(t, []string{"a"}, hot)
+	assert.Equal(t, []string{"b"}, ghost)
+	assert.Equal(t, 1, c.Len())
+	c.Put("c", 4)
+	in, hot, _ = queues(t, c)
+	assert.Equal(t, []string{"c"}, in)
+	assert.Empty(t, hot)
+}
@@ -1 +1,289 @@
+//
+// Package cuckoofilter implements Cuckoo filter probabilistic data structure.
+//
+// Filter stores short fingerprints of items in buckets of fixed size.
+// Every item has two candidate buckets, second one is derived from first
+// bucket index and fingerprint only (partial-key cuckoo hashing):
+//
+//	i1 = hash(x)
+//	i2 = i1 xor hash(fingerprint(x))
+//
+// This allows to relocate fingerprints without original item and,
+// unlike Bloom filter, supports deletion.
+//
+// https://en.wikipedia.org/wiki/Cuckoo_filter
+// https://www.cs.cmu.edu/~dga/papers/cuckoo-conext2014.pdf
+//
 package cuckoofilter
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+)
+
+const (
+	// DefaultBucketSize is number of fingerprints per bucket
+	// recommended by original paper
+	DefaultBucketSize = 4
+	// DefaultFingerprintBits is fingerprint size giving ~0.01% false-positive rate
+	DefaultFingerprintBits = 16
+	// MaxKicks is maximum number of relocations before insert gives up
+	MaxKicks = 500
+	// maxLoadFactor after which number of buckets is doubled at construction
+	maxLoadFactor = 0.95
+	// defaultSeed is mixed into every hash computation
+	defaultSeed uint64 = 0x9e3779b97f4a7c15
+)
+
+// ErrFilterFull is returned when item cannot be placed into filter
+// after MaxKicks relocations
+var ErrFilterFull = errors.New("cuckoo filter is full")
+
+// CuckooFilter is cuckoo filter backed by flat array of fingerprints
+// Fingerprint value 0 denotes an empty slot
+type CuckooFilter struct {
+	slots      []uint32
+	numBuckets uint64 // always power of two
+	bucketSize uint64
+	fpBits     uint
+	count      uint64
+	seed       uint64
+	rnd        *rand.Rand
+}
+
+// Stats of a filter
+type Stats struct {
+	Count           uint64
+	Capacity        uint64
+	Buckets         uint64
+	BucketSize      uint64
+	FingerprintBits uint
+	LoadFactor      float64
+}
+
+// NewCuckooFilter creates filter able to hold at least capacity items
+// with given number of slots per bucket and fingerprint size in bits
+func NewCuckooFilter(capacity, bucketSize, fingerprintBits int) (*CuckooFilter, error) {
+	return NewCuckooFilterWithSeed(capacity, bucketSize, fingerprintBits, defaultSeed)
+}
+
+// NewCuckooFilterWithSeed creates filter with custom hash seed
+// Filters must share the seed to place same items into same buckets
+func NewCuckooFilterWithSeed(capacity, bucketSize, fingerprintBits int, seed uint64) (*CuckooFilter, error) {
+	if capacity <= 0 {
+		return nil, errors.New("capacity must be positive")
+	}
+	if bucketSize <= 0 {
+		return nil, errors.New("bucket size must be positive")
+	}
+	if fingerprintBits < 1 || fingerprintBits > 32 {
+		return nil, errors.New("fingerprint size must be in range [1, 32] bits")
+	}
+	numBuckets := nextPowerOfTwo(uint64((capacity + bucketSize - 1) / bucketSize))
+	if float64(capacity)/float64(numBuckets*uint64(bucketSize)) > maxLoadFactor {
+		numBuckets <<= 1
+	}
+	return newCuckooFilter(numBuckets, uint64(bucketSize), uint(fingerprintBits), seed), nil
+}
+
+// newCuckooFilter with exact number of buckets
+func newCuckooFilter(numBuckets, bucketSize uint64, fpBits uint, seed uint64) *CuckooFilter {
+	return &CuckooFilter{
+		slots:      make([]uint32, numBuckets*bucketSize),
+		numBuckets: numBuckets,
+		bucketSize: bucketSize,
+		fpBits:     fpBits,
+		seed:       seed,
+		rnd:        rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// Count of items in filter
+func (f *CuckooFilter) Count() uint64 {
+	return f.count
+}
+
+// Stats returns filter parameters and current load factor
+func (f *CuckooFilter) Stats() Stats {
+	capacity := uint64(len(f.slots))
+	return Stats{
+		Count:           f.count,
+		Capacity:        capacity,
+		Buckets:         f.numBuckets,
+		BucketSize:      f.bucketSize,
+		FingerprintBits: f.fpBits,
+		LoadFactor:      float64(f.count) / float64(capacity),
+	}
+}
+
+// Insert data into filter
+// Returns ErrFilterFull if there is no room for the item,
+// in this case filter stays unchanged
+func (f *CuckooFilter) Insert(data []byte) error {
+	return f.insert(f.indexAndFingerprint(hash(f.seed, data)))
+}
+
+// InsertString inserts string into filter
+func (f *CuckooFilter) InsertString(s string) error {
+	return f.insert(f.indexAndFingerprint(hash(f.seed, s)))
+}
+
+// Lookup returns true if data is possibly in filter
+// False means data is definitely not in filter
+func (f *CuckooFilter) Lookup(data []byte) bool {
+	return f.lookup(f.indexAndFingerprint(hash(f.seed, data)))
+}
+
+// LookupString returns true if string is possibly in filter
+func (f *CuckooFilter) LookupString(s string) bool {
+	return f.lookup(f.indexAndFingerprint(hash(f.seed, s)))
+}
+
+// Delete one copy of data from filter
+// Returns false if data was not found
+// Deleting item which was never inserted may remove other item
+// which shares same fingerprint and bucket
+func (f *CuckooFilter) Delete(data []byte) bool {
+	return f.delete(f.indexAndFingerprint(hash(f.seed, data)))
+}
+
+// DeleteString deletes one copy of string from filter
+func (f *CuckooFilter) DeleteString(s string) bool {
+	return f.delete(f.indexAndFingerprint(hash(f.seed, s)))
+}
+
+// Reset removes all items
+func (f *CuckooFilter) Reset() {
+	clear(f.slots)
+	f.count = 0
+}
+
+func (f *CuckooFilter) insert(i1 uint64, fp uint32) error {
+	i2 := f.altIndex(i1, fp)
+	if f.insertInto(i1, fp) || f.insertInto(i2, fp) {
+		f.count++
+		return nil
+	}
+
+	// both buckets are full, relocate existing fingerprints
+	// positions of swapped slots are recorded so filter can be restored
+	var (
+		i    = i1
+		path = make([]uint64, 0, MaxKicks)
+	)
+	if f.rnd.IntN(2) == 1 {
+		i = i2
+	}
+	for n := 0; n < MaxKicks; n++ {
+		pos := i*f.bucketSize + f.rnd.Uint64N(f.bucketSize)
+		fp, f.slots[pos] = f.slots[pos], fp
+		path = append(path, pos)
+		i = f.altIndex(i, fp)
+		if f.insertInto(i, fp) {
+			f.count++
+			return nil
+		}
+	}
+
+	// roll back relocations in reverse order
+	for n := len(path) - 1; n >= 0; n-- {
+		fp, f.slots[path[n]] = f.slots[path[n]], fp
+	}
+
+	return ErrFilterFull
+}
+
+func (f *CuckooFilter) lookup(i1 uint64, fp uint32) bool {
+	return f.bucketHas(i1, fp) || f.bucketHas(f.altIndex(i1, fp), fp)
+}
+
+func (f *CuckooFilter) delete(i1 uint64, fp uint32) bool {
+	if f.deleteFrom(i1, fp) || f.deleteFrom(f.altIndex(i1, fp), fp) {
+		f.count--
+		return true
+	}
+	return false
+}
+
+// insertInto puts fingerprint in first empty slot of bucket i
+func (f *CuckooFilter) insertInto(i uint64, fp uint32) bool {
+	bucket := f.bucket(i)
+	for j := range bucket {
+		if bucket[j] == 0 {
+			bucket[j] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// deleteFrom removes first occurrence of fingerprint from bucket i
+func (f *CuckooFilter) deleteFrom(i uint64, fp uint32) bool {
+	bucket := f.bucket(i)
+	for j := range bucket {
+		if bucket[j] == fp {
+			bucket[j] = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (f *CuckooFilter) bucketHas(i uint64, fp uint32) bool {
+	for _, v := range f.bucket(i) {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// bucket returns slots of bucket i
+func (f *CuckooFilter) bucket(i uint64) []uint32 {
+	return f.slots[i*f.bucketSize : (i+1)*f.bucketSize]
+}
+
+// indexAndFingerprint derives primary bucket index and non-zero fingerprint
+// from lower and upper halves of item hash
+func (f *CuckooFilter) indexAndFingerprint(h uint64) (uint64, uint32) {
+	fp := uint32(h>>32) & (1<<f.fpBits - 1)
+	if fp == 0 {
+		fp = 1
+	}
+	return h & (f.numBuckets - 1), fp
+}
+
+// altIndex returns alternative bucket for fingerprint stored in bucket i
+// altIndex(altIndex(i, fp), fp) == i because numBuckets is power of two
+func (f *CuckooFilter) altIndex(i uint64, fp uint32) uint64 {
+	return (i ^ mix64(uint64(fp))) & (f.numBuckets - 1)
+}
+
+// hash is seeded FNV-1a passed through splitmix64 finalizer
+func hash[T ~string | ~[]byte](seed uint64, data T) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64) ^ seed
+	for i := 0; i < len(data); i++ {
+		h ^= uint64(data[i])
+		h *= prime64
+	}
+	return mix64(h)
+}
+
+// mix64 is splitmix64 finalizer
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(n-1)
+}
@@ -1 +1,126 @@
 package cuckoofilter
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCuckooFilter(t *testing.T) {
+	_, err := NewCuckooFilter(0, DefaultBucketSize, DefaultFingerprintBits)
+	assert.Error(t, err)
+	_, err = NewCuckooFilter(100, 0, DefaultFingerprintBits)
+	assert.Error(t, err)
+	_, err = NewCuckooFilter(100, DefaultBucketSize, 0)
+	assert.Error(t, err)
+	_, err = NewCuckooFilter(100, DefaultBucketSize, 33)
+	assert.Error(t, err)
+
+	f, err := NewCuckooFilter(900, DefaultBucketSize, DefaultFingerprintBits)
+	assert.NoError(t, err)
+	s := f.Stats()
+	assert.Equal(t, uint64(256), s.Buckets)
+	assert.Equal(t, uint64(4), s.BucketSize)
+	assert.Equal(t, uint64(1024), s.Capacity)
+	assert.Equal(t, uint(16), s.FingerprintBits)
+
+	// 1000 items in 1024 slots exceeds maximum load factor
+	f, err = NewCuckooFilter(1000, 1, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2048), f.Stats().Buckets)
+}
+
+func TestCuckooFilter_InsertLookupDelete(t *testing.T) {
+	f, err := NewCuckooFilter(100, DefaultBucketSize, DefaultFingerprintBits)
+	assert.NoError(t, err)
+
+	assert.False(t, f.Lookup([]byte("foo")))
+	assert.NoError(t, f.Insert([]byte("foo")))
+	assert.NoError(t, f.InsertString("bar"))
+	assert.True(t, f.Lookup([]byte("foo")))
+	assert.True(t, f.LookupString("bar"))
+	assert.False(t, f.LookupString("buzz"))
+	assert.Equal(t, uint64(2), f.Count())
+
+	// duplicates are stored as separate copies
+	assert.NoError(t, f.InsertString("foo"))
+	assert.True(t, f.DeleteString("foo"))
+	assert.True(t, f.LookupString("foo"))
+	assert.True(t, f.Delete([]byte("foo")))
+	assert.False(t, f.LookupString("foo"))
+	assert.False(t, f.DeleteString("foo"))
+	assert.Equal(t, uint64(1), f.Count())
+
+	f.Reset()
+	assert.False(t, f.LookupString("bar"))
+	assert.Equal(t, uint64(0), f.Count())
+}
+
+func TestCuckooFilter_Full(t *testing.T) {
+	f, err := NewCuckooFilter(64, 2, 12)
+	assert.NoError(t, err)
+	capacity := f.Stats().Capacity
+
+	var inserted []string
+	for i := 0; ; i++ {
+		s := strconv.Itoa(i)
+		err := f.InsertString(s)
+		if err != nil {
+			assert.True(t, errors.Is(err, ErrFilterFull))
+			break
+		}
+		inserted = append(inserted, s)
+	}
+
+	s := f.Stats()
+	assert.Equal(t, uint64(len(inserted)), s.Count)
+	assert.LessOrEqual(t, s.Count, capacity)
+	assert.Greater(t, s.LoadFactor, 0.8)
+
+	// failed insert must not lose previously inserted items
+	for _, v := range inserted {
+		assert.True(t, f.LookupString(v))
+	}
+	for _, v := range inserted {
+		assert.True(t, f.DeleteString(v))
+	}
+	assert.Equal(t, uint64(0), f.Count())
+	assert.Equal(t, float64(0), f.Stats().LoadFactor)
+}
+
+func TestCuckooFilter_FalsePositiveRate(t *testing.T) {
+	const n = 10000
+	f, err := NewCuckooFilter(n, DefaultBucketSize, 12)
+	assert.NoError(t, err)
+	for i := 0; i < n; i++ {
+		assert.NoError(t, f.InsertString(strconv.Itoa(i)))
+	}
+	var fp int
+	for i := n; i < 11*n; i++ {
+		if f.LookupString(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	// upper bound is 2*b/2^f ~ 0.002
+	assert.Less(t, float64(fp)/float64(10*n), 0.004)
+}
+
+func BenchmarkCuckooFilter_Insert(b *testing.B) {
+	f, _ := NewCuckooFilter(b.N+1, DefaultBucketSize, DefaultFingerprintBits)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = f.InsertString(strconv.Itoa(i))
+	}
+}
+
+func BenchmarkCuckooFilter_Lookup(b *testing.B) {
+	f, _ := NewCuckooFilter(1000, DefaultBucketSize, DefaultFingerprintBits)
+	data := []byte("benchmark")
+	_ = f.Insert(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Lookup(data)
+	}
+}
@@ -0,0 +1,196 @@
+package cuckoofilter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/bits"
+)
+
+// Binary format, all integers are little-endian:
+//
+//	magic       [4]byte  "CKOF"
+//	version     uint8
+//	numBuckets  uint64
+//	bucketSize  uint64
+//	fpBits      uint8
+//	seed        uint64
+//	count       uint64
+//	slots       [numBuckets*bucketSize]uintN  N is fpBits rounded up to whole bytes
+//	checksum    uint32   CRC-32 (IEEE) of all preceding bytes
+
+const (
+	encodingVersion       uint8 = 1
+	encodingHeaderSize          = 4 + 1 + 8 + 8 + 1 + 8 + 8
+	encodingMaxBucketSize       = 1 << 16
+	encodingMaxSlots            = 1 << 40
+)
+
+var encodingMagic = [4]byte{'C', 'K', 'O', 'F'}
+
+// ErrInvalidData is returned when decoded data is corrupt
+// or does not describe a cuckoo filter
+var ErrInvalidData = errors.New("invalid cuckoo filter data")
+
+// encodingHeader holds filter parameters stored in header
+type encodingHeader struct {
+	numBuckets uint64
+	bucketSize uint64
+	fpBits     uint
+	seed       uint64
+	count      uint64
+}
+
+// payloadSize is size of slots and checksum following the header
+func (h encodingHeader) payloadSize() uint64 {
+	return h.numBuckets*h.bucketSize*slotBytes(h.fpBits) + 4
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *CuckooFilter) MarshalBinary() ([]byte, error) {
+	width := slotBytes(f.fpBits)
+	buf := make([]byte, 0, encodingHeaderSize+uint64(len(f.slots))*width+4)
+	buf = append(buf, encodingMagic[:]...)
+	buf = append(buf, encodingVersion)
+	buf = binary.LittleEndian.AppendUint64(buf, f.numBuckets)
+	buf = binary.LittleEndian.AppendUint64(buf, f.bucketSize)
+	buf = append(buf, uint8(f.fpBits))
+	buf = binary.LittleEndian.AppendUint64(buf, f.seed)
+	buf = binary.LittleEndian.AppendUint64(buf, f.count)
+	for _, fp := range f.slots {
+		for j := uint64(0); j < width; j++ {
+			buf = append(buf, byte(fp>>(8*j)))
+		}
+	}
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+// Filter is left unchanged if data is invalid
+func (f *CuckooFilter) UnmarshalBinary(data []byte) error {
+	h, err := decodeHeader(data)
+	if err != nil {
+		return err
+	}
+	if expected := encodingHeaderSize + h.payloadSize(); uint64(len(data)) != expected {
+		return fmt.Errorf("%w: length is %d bytes, expected %d for %d buckets of size %d",
+			ErrInvalidData, len(data), expected, h.numBuckets, h.bucketSize)
+	}
+
+	payload, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if actual := crc32.ChecksumIEEE(payload); actual != sum {
+		return fmt.Errorf("%w: checksum mismatch: stored %08x, computed %08x",
+			ErrInvalidData, sum, actual)
+	}
+
+	var (
+		filter = newCuckooFilter(h.numBuckets, h.bucketSize, h.fpBits, h.seed)
+		width  = slotBytes(h.fpBits)
+		count  uint64
+	)
+	payload = payload[encodingHeaderSize:]
+	for j := range filter.slots {
+		var fp uint32
+		for b := uint64(0); b < width; b++ {
+			fp |= uint32(payload[uint64(j)*width+b]) << (8 * b)
+		}
+		if bits.Len32(fp) > int(h.fpBits) {
+			return fmt.Errorf("%w: fingerprint %x exceeds %d bits", ErrInvalidData, fp, h.fpBits)
+		}
+		if fp != 0 {
+			count++
+		}
+		filter.slots[j] = fp
+	}
+	if count != h.count {
+		return fmt.Errorf("%w: header count %d does not match %d stored fingerprints",
+			ErrInvalidData, h.count, count)
+	}
+	filter.count = count
+
+	*f = *filter
+	return nil
+}
+
+// WriteTo implements io.WriterTo
+func (f *CuckooFilter) WriteTo(w io.Writer) (int64, error) {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom
+// It reads exactly one encoded filter from r
+func (f *CuckooFilter) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, encodingHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil {
+		return int64(n), fmt.Errorf("%w: reading header: %w", ErrInvalidData, err)
+	}
+	h, err := decodeHeader(header)
+	if err != nil {
+		return int64(n), err
+	}
+
+	// payload is read through limited reader so corrupt header
+	// does not cause allocation of arbitrary size
+	var (
+		size = int64(h.payloadSize())
+		buf  = bytes.NewBuffer(header)
+	)
+	read, err := io.Copy(buf, io.LimitReader(r, size))
+	if err != nil {
+		return int64(n) + read, err
+	}
+	if read != size {
+		return int64(n) + read, fmt.Errorf("%w: reading payload: %w", ErrInvalidData, io.ErrUnexpectedEOF)
+	}
+
+	return int64(n) + read, f.UnmarshalBinary(buf.Bytes())
+}
+
+// decodeHeader validates header and returns filter parameters
+func decodeHeader(data []byte) (encodingHeader, error) {
+	var h encodingHeader
+	if len(data) < encodingHeaderSize {
+		return h, fmt.Errorf("%w: data is too short (%d bytes)", ErrInvalidData, len(data))
+	}
+	if !bytes.Equal(data[:4], encodingMagic[:]) {
+		return h, fmt.Errorf("%w: unknown magic %q", ErrInvalidData, data[:4])
+	}
+	if v := data[4]; v != encodingVersion {
+		return h, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, v)
+	}
+	h.numBuckets = binary.LittleEndian.Uint64(data[5:])
+	h.bucketSize = binary.LittleEndian.Uint64(data[13:])
+	h.fpBits = uint(data[21])
+	h.seed = binary.LittleEndian.Uint64(data[22:])
+	h.count = binary.LittleEndian.Uint64(data[30:])
+	if h.numBuckets == 0 || h.numBuckets&(h.numBuckets-1) != 0 {
+		return h, fmt.Errorf("%w: number of buckets %d is not a power of two", ErrInvalidData, h.numBuckets)
+	}
+	if h.bucketSize == 0 || h.bucketSize > encodingMaxBucketSize {
+		return h, fmt.Errorf("%w: invalid bucket size %d", ErrInvalidData, h.bucketSize)
+	}
+	if h.numBuckets > encodingMaxSlots/h.bucketSize {
+		return h, fmt.Errorf("%w: filter of %d buckets is too large", ErrInvalidData, h.numBuckets)
+	}
+	if h.fpBits < 1 || h.fpBits > 32 {
+		return h, fmt.Errorf("%w: invalid fingerprint size %d", ErrInvalidData, h.fpBits)
+	}
+	if h.count > h.numBuckets*h.bucketSize {
+		return h, fmt.Errorf("%w: count %d exceeds capacity", ErrInvalidData, h.count)
+	}
+	return h, nil
+}
+
+// slotBytes is number of bytes used to store single fingerprint
+func slotBytes(fpBits uint) uint64 {
+	return uint64(fpBits+7) / 8
+}
@@ -0,0 +1,104 @@
+package cuckoofilter
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFilter(t *testing.T, fpBits int, seed uint64) *CuckooFilter {
+	f, err := NewCuckooFilterWithSeed(1000, DefaultBucketSize, fpBits, seed)
+	assert.NoError(t, err)
+	for i := 0; i < 500; i++ {
+		assert.NoError(t, f.InsertString(strconv.Itoa(i)))
+	}
+	return f
+}
+
+func TestCuckooFilter_MarshalBinary(t *testing.T) {
+	for _, fpBits := range []int{4, 8, 12, 16, 24, 32} {
+		t.Run(strconv.Itoa(fpBits), func(t *testing.T) {
+			f := newTestFilter(t, fpBits, 42)
+			data, err := f.MarshalBinary()
+			assert.NoError(t, err)
+
+			var decoded CuckooFilter
+			assert.NoError(t, decoded.UnmarshalBinary(data))
+			assert.Equal(t, f.slots, decoded.slots)
+			assert.Equal(t, f.Stats(), decoded.Stats())
+			assert.Equal(t, f.seed, decoded.seed)
+			for i := 0; i < 500; i++ {
+				assert.True(t, decoded.LookupString(strconv.Itoa(i)))
+			}
+			// decoded filter stays fully functional
+			assert.True(t, decoded.DeleteString("0"))
+			assert.NoError(t, decoded.InsertString("foo"))
+		})
+	}
+}
+
+func TestCuckooFilter_UnmarshalBinary_Invalid(t *testing.T) {
+	data, err := newTestFilter(t, DefaultFingerprintBits, 42).MarshalBinary()
+	assert.NoError(t, err)
+
+	corrupt := func(fn func(b []byte) []byte) []byte {
+		return fn(bytes.Clone(data))
+	}
+	tests := map[string][]byte{
+		"empty":       nil,
+		"truncated":   data[:len(data)-1],
+		"trailing":    append(bytes.Clone(data), 0),
+		"magic":       corrupt(func(b []byte) []byte { b[0] = 'X'; return b }),
+		"version":     corrupt(func(b []byte) []byte { b[4] = 99; return b }),
+		"buckets":     corrupt(func(b []byte) []byte { b[5]++; return b }),
+		"bucket size": corrupt(func(b []byte) []byte { b[13] = 0; return b }),
+		"fingerprint": corrupt(func(b []byte) []byte { b[21] = 33; return b }),
+		"payload":     corrupt(func(b []byte) []byte { b[encodingHeaderSize] ^= 1; return b }),
+		"checksum":    corrupt(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }),
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFilter(t, 8, 7)
+			before := f.slots
+			err := f.UnmarshalBinary(input)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidData))
+			assert.Equal(t, before, f.slots)
+			assert.Equal(t, uint64(500), f.Count())
+		})
+	}
+}
+
+func TestCuckooFilter_WriteToReadFrom(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		first  = newTestFilter(t, 8, 1)
+		second = newTestFilter(t, 16, 2)
+	)
+	n1, err := first.WriteTo(&buf)
+	assert.NoError(t, err)
+	n2, err := second.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n1+n2)
+
+	var decoded CuckooFilter
+	n, err := decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n1, n)
+	assert.Equal(t, first.slots, decoded.slots)
+
+	n, err = decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, n2, n)
+	assert.Equal(t, second.slots, decoded.slots)
+
+	_, err = decoded.ReadFrom(&buf)
+	assert.True(t, errors.Is(err, ErrInvalidData))
+
+	data, _ := first.MarshalBinary()
+	_, err = decoded.ReadFrom(bytes.NewReader(data[:len(data)-10]))
+	assert.True(t, errors.Is(err, ErrInvalidData))
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+
+	"github.com/hasansino/gobasics/structures/heap"
+)
+
+// ErrNoPath is returned when target is unreachable from source
+var ErrNoPath = errors.New("no path between nodes")
+
+// Heuristic estimates cost of cheapest path from node n to search goal
+// Heuristic which never overestimates (admissible) guarantees optimal path
+type Heuristic[K comparable, W Number] func(n K) W
+
+// ZeroHeuristic makes A* behave exactly like Dijkstra
+func ZeroHeuristic[K comparable, W Number](K) W { return 0 }
+
+// SearchResult of a path search between two nodes
+type SearchResult[K comparable, W Number] struct {
+	// Path from source to target, both included
+	Path []K
+	// Cost is sum of weights of edges on path
+	Cost W
+	// Expanded is number of nodes taken from priority queue and expanded
+	Expanded int
+}
+
+// astarItem is priority queue entry
+type astarItem[W Number] struct {
+	node int
+	g, f W
+}
+
+// astarCompare orders queue entries by estimated total cost f = g + h,
+// ties are broken in favour of entries closer to goal (larger g)
+func astarCompare[W Number](a, b astarItem[W]) int {
+	if a.f != b.f {
+		return cmp.Compare(a.f, b.f)
+	}
+	if a.g != b.g {
+		return cmp.Compare(b.g, a.g)
+	}
+	return cmp.Compare(a.node, b.node)
+}
+
+// AStar finds cheapest path between two nodes guided by heuristic
+// Priority of queued node is decreased when cheaper path to it is found,
+// expanded node is queued again, so admissible but inconsistent
+// heuristics still produce optimal path
+// Returns ErrNoPath if target is unreachable
+// https://en.wikipedia.org/wiki/A*_search_algorithm
+func (g *Graph[K, V, W]) AStar(from, to K, h Heuristic[K, W]) (*SearchResult[K, W], error) {
+	i, err := g.slot(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := g.slot(to)
+	if err != nil {
+		return nil, err
+	}
+	if g.hasNegativeWeights() {
+		return nil, ErrNegativeWeight
+	}
+
+	var (
+		res    = &SearchResult[K, W]{}
+		s      = newSearch[W](i, len(g.nodes))
+		queued = make([]*heap.Handle[astarItem[W]], len(g.nodes))
+		open   = heap.NewIndexedHeap(astarCompare[W])
+	)
+
+	open.Push(astarItem[W]{node: i, f: h(from)})
+	for open.Len() > 0 {
+		item, _ := open.Pop()
+		if item.node == target {
+			res.Path, res.Cost = g.keys(s.path(target)), item.g
+			return res, nil
+		}
+		res.Expanded++
+		for _, a := range g.nodes[item.node].out {
+			d := item.g + a.weight
+			if s.reached[a.to] && d >= s.dist[a.to] {
+				continue
+			}
+			s.dist[a.to], s.reached[a.to] = d, true
+			s.pred[a.to] = item.node
+			next := astarItem[W]{node: a.to, g: d, f: d + h(g.key(a.to))}
+			if queued[a.to] != nil && open.Contains(queued[a.to]) {
+				open.Update(queued[a.to], next)
+			} else {
+				queued[a.to] = open.Push(next)
+			}
+		}
+	}
+
+	return nil, ErrNoPath
+}
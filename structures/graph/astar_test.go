@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_AStar(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+
+	res, err := g.AStar(0, 4, ZeroHeuristic[int, int])
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 5, 6, 3, 4}, res.Path)
+	assert.Equal(t, 10, res.Cost)
+
+	res, err = g.AStar(2, 2, ZeroHeuristic[int, int])
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, res.Path)
+	assert.Equal(t, 0, res.Cost)
+	assert.Equal(t, 0, res.Expanded)
+
+	_, err = g.AStar(0, 7, ZeroHeuristic[int, int])
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	g.RemoveEdge(0, 1)
+	g.RemoveEdge(0, 2)
+	res, err = g.AStar(0, 4, ZeroHeuristic[int, int])
+	assert.ErrorIs(t, err, ErrNoPath)
+	assert.Nil(t, res)
+
+	g.CreateEdge(0, 1, -1)
+	_, err = g.AStar(0, 4, ZeroHeuristic[int, int])
+	assert.ErrorIs(t, err, ErrNegativeWeight)
+}
+
+func TestGraph_AStar_Inconsistent(t *testing.T) {
+	// admissible but inconsistent heuristic makes node 2 expanded
+	// through expensive path first, it has to be re-opened later
+	//
+	//	0 -1-> 1 -1-> 2 -1-> 3 -10-> 4
+	//	 \           ^
+	//	  ------3----
+	g := buildGraph(testNodes[:5], []struct{ i, j, w int }{
+		{0, 1, 1}, {1, 2, 1}, {0, 2, 3}, {2, 3, 1}, {3, 4, 10},
+	})
+	h := map[int]int{0: 0, 1: 12, 2: 0, 3: 0, 4: 0}
+	res, err := g.AStar(0, 4, func(n int) int { return h[n] })
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, res.Path)
+	assert.Equal(t, 13, res.Cost)
+}
+
+func TestGrid(t *testing.T) {
+	gr := NewGrid(3, 2, false)
+	assert.Equal(t, 6, gr.Len())
+	assert.Equal(t, 7, gr.EdgeCount())
+	found, ok := gr.BreathFirstSearch(Point{}, func(p Point, _ struct{}) bool { return p.X+p.Y == 3 })
+	assert.True(t, ok)
+	assert.Equal(t, Point{X: 2, Y: 1}, found)
+	assert.True(t, gr.Adjacent(Point{X: 0, Y: 0}, Point{X: 1, Y: 0}))
+	assert.True(t, gr.Adjacent(Point{X: 0, Y: 0}, Point{X: 0, Y: 1}))
+	assert.False(t, gr.Adjacent(Point{X: 0, Y: 0}, Point{X: 1, Y: 1}))
+	assert.False(t, gr.Adjacent(Point{X: 0, Y: 0}, Point{X: 3, Y: 0}))
+
+	gr = NewGrid(3, 2, true)
+	w, ok := gr.Weight(Point{X: 0, Y: 0}, Point{X: 1, Y: 1})
+	assert.True(t, ok)
+	assert.Equal(t, GridDiagonalCost, w)
+
+	center := Point{X: 1, Y: 1}
+	gr.Block(center)
+	assert.Empty(t, gr.Edges(center))
+	for _, p := range gr.Nodes() {
+		assert.False(t, gr.Adjacent(p, center))
+	}
+}
+
+func TestGrid_AStar(t *testing.T) {
+	// 20x20 grid with wall in the middle
+	//
+	//	. . . . # . .
+	//	. S . . # . G
+	//	. . . . # . .
+	//	. . . . . . .
+	build := func(diagonal bool) *Grid {
+		gr := NewGrid(20, 20, diagonal)
+		for y := 0; y < 15; y++ {
+			gr.Block(Point{X: 10, Y: y})
+		}
+		return gr
+	}
+	tests := map[string]struct {
+		diagonal   bool
+		heuristics map[string]func(gr *Grid, goal Point) Heuristic[Point, int]
+	}{
+		"4-connected": {
+			diagonal: false,
+			heuristics: map[string]func(gr *Grid, goal Point) Heuristic[Point, int]{
+				"manhattan": (*Grid).Manhattan,
+				"euclidean": (*Grid).Euclidean,
+				"octile":    (*Grid).Octile,
+			},
+		},
+		"8-connected": {
+			diagonal: true,
+			heuristics: map[string]func(gr *Grid, goal Point) Heuristic[Point, int]{
+				"euclidean": (*Grid).Euclidean,
+				"octile":    (*Grid).Octile,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var (
+				gr       = build(tt.diagonal)
+				from, to = Point{X: 2, Y: 2}, Point{X: 18, Y: 2}
+			)
+			expected, err := gr.DijkstraTo(from, to)
+			assert.NoError(t, err)
+			dijkstra, err := gr.AStar(from, to, ZeroHeuristic[Point, int])
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Distances[to], dijkstra.Cost)
+			assert.Equal(t, expected.Expanded, dijkstra.Expanded)
+
+			for hName, h := range tt.heuristics {
+				res, err := gr.AStar(from, to, h(gr, to))
+				assert.NoError(t, err, hName)
+				assert.Equal(t, expected.Distances[to], res.Cost, hName)
+				assert.Equal(t, from, res.Path[0], hName)
+				assert.Equal(t, to, res.Path[len(res.Path)-1], hName)
+				// path goes around the wall
+				assert.Contains(t, res.Path, Point{X: 10, Y: 15}, hName)
+				assert.Less(t, res.Expanded, dijkstra.Expanded, hName)
+			}
+		})
+	}
+}
+
+func TestGrid_Heuristics(t *testing.T) {
+	gr := NewGrid(10, 10, true)
+	goal := Point{}
+	// 3 diagonal and 4 straight moves
+	p := Point{X: 7, Y: 3}
+	assert.Equal(t, 100, gr.Manhattan(goal)(p))
+	assert.Equal(t, 75, gr.Euclidean(goal)(p))
+	assert.Equal(t, 82, gr.Octile(goal)(p))
+
+	sp, err := gr.Dijkstra(goal)
+	assert.NoError(t, err)
+	assert.Equal(t, 82, sp.Distances[p])
+	// admissible heuristics never overestimate
+	for _, p := range gr.Nodes() {
+		assert.LessOrEqual(t, gr.Euclidean(goal)(p), sp.Distances[p])
+		assert.LessOrEqual(t, gr.Octile(goal)(p), sp.Distances[p])
+	}
+}
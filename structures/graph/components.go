@@ -0,0 +1,310 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Components is partition of graph nodes into strongly connected components
+type Components[K comparable] struct {
+	// Membership maps every node to index of component it belongs to
+	Membership map[K]int
+	// Groups lists nodes of every component in insertion order,
+	// components are ordered by their first inserted node
+	Groups [][]K
+}
+
+// Count returns number of components
+func (c *Components[K]) Count() int {
+	return len(c.Groups)
+}
+
+// components partition of node slots, label[n] is -1 for removed slots
+type components struct {
+	membership []int
+	groups     [][]int
+}
+
+// newComponents builds components from arbitrary labeling of slots,
+// renumbering them so result does not depend on algorithm used
+func newComponents(label []int) *components {
+	var (
+		c     = &components{membership: make([]int, len(label))}
+		index = make(map[int]int)
+	)
+	for n, l := range label {
+		if l == -1 {
+			c.membership[n] = -1
+			continue
+		}
+		i, ok := index[l]
+		if !ok {
+			i = len(c.groups)
+			index[l] = i
+			c.groups = append(c.groups, nil)
+		}
+		c.membership[n] = i
+		c.groups[i] = append(c.groups[i], n)
+	}
+	return c
+}
+
+// publicComponents converts components of slots to components of nodes
+func (g *Graph[K, V, W]) publicComponents(c *components) *Components[K] {
+	ret := &Components[K]{
+		Membership: make(map[K]int, len(g.index)),
+		Groups:     make([][]K, len(c.groups)),
+	}
+	for n, i := range c.membership {
+		if i != -1 {
+			ret.Membership[g.key(n)] = i
+		}
+	}
+	for i, group := range c.groups {
+		ret.Groups[i] = g.keys(group)
+	}
+	return ret
+}
+
+// StronglyConnectedComponents finds maximal sets of nodes
+// where every node is reachable from every other one
+// Tarjan's algorithm finds all components in single depth-first search
+// https://en.wikipedia.org/wiki/Tarjan%27s_strongly_connected_components_algorithm
+func (g *Graph[K, V, W]) StronglyConnectedComponents() *Components[K] {
+	return g.publicComponents(g.tarjan())
+}
+
+// tarjan labels every slot with its strongly connected component
+func (g *Graph[K, V, W]) tarjan() *components {
+	var (
+		index   = make([]int, len(g.nodes)) // discovery order starting from 1, 0 if unvisited
+		low     = make([]int, len(g.nodes))
+		label   = make([]int, len(g.nodes))
+		onStack = make([]bool, len(g.nodes))
+		stack   []int
+		counter int
+	)
+	for n := range label {
+		label[n] = -1
+	}
+
+	var connect func(n int)
+	connect = func(n int) {
+		counter++
+		index[n], low[n] = counter, counter
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, a := range g.nodes[n].out {
+			if index[a.to] == 0 {
+				connect(a.to)
+				low[n] = min(low[n], low[a.to])
+			} else if onStack[a.to] {
+				low[n] = min(low[n], index[a.to])
+			}
+		}
+
+		// n is root of component, pop it from stack
+		if low[n] == index[n] {
+			for {
+				j := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[j] = false
+				label[j] = n
+				if j == n {
+					break
+				}
+			}
+		}
+	}
+
+	for n := range g.nodes {
+		if g.exists(n) && index[n] == 0 {
+			connect(n)
+		}
+	}
+	return newComponents(label)
+}
+
+// Kosaraju finds strongly connected components same as StronglyConnectedComponents,
+// using two passes of depth-first search: over graph and over its transpose
+// https://en.wikipedia.org/wiki/Kosaraju%27s_algorithm
+func (g *Graph[K, V, W]) Kosaraju() *Components[K] {
+	var (
+		visited = make([]bool, len(g.nodes))
+		order   = make([]int, 0, len(g.index)) // slots in order of finishing
+		label   = make([]int, len(g.nodes))
+	)
+	for n := range label {
+		label[n] = -1
+	}
+
+	var visit func(n int)
+	visit = func(n int) {
+		visited[n] = true
+		for _, a := range g.nodes[n].out {
+			if !visited[a.to] {
+				visit(a.to)
+			}
+		}
+		order = append(order, n)
+	}
+	for n := range g.nodes {
+		if g.exists(n) && !visited[n] {
+			visit(n)
+		}
+	}
+
+	var assign func(n, root int)
+	assign = func(n, root int) {
+		label[n] = root
+		// following edges backwards
+		for _, j := range g.nodes[n].in {
+			if label[j] == -1 {
+				assign(j, root)
+			}
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if n := order[i]; label[n] == -1 {
+			assign(n, n)
+		}
+	}
+	return g.publicComponents(newComponents(label))
+}
+
+// Condensation contracts every strongly connected component into single node
+// Node of resulting directed graph is keyed by component index and holds
+// nodes of that component, edge between components has minimum weight
+// of edges connecting them
+// Condensation is always acyclic
+// https://en.wikipedia.org/wiki/Strongly_connected_component#Definitions
+func (g *Graph[K, V, W]) Condensation() (*Graph[int, []K, W], *Components[K]) {
+	var (
+		c  = g.tarjan()
+		cg = NewGraph[int, []K, W](Directed)
+	)
+	for i, group := range c.groups {
+		_ = cg.InsertNode(i, g.keys(group)) // component indexes are unique
+	}
+	for _, e := range g.arcList() {
+		ci, cj := c.membership[e.from], c.membership[e.to]
+		if ci == cj {
+			continue
+		}
+		if w, ok := cg.Weight(ci, cj); !ok || e.weight < w {
+			_ = cg.CreateEdge(ci, cj, e.weight) // every component has node
+		}
+	}
+	return cg, g.publicComponents(c)
+}
+
+// Bridges returns edges whose removal increases number of connected components
+// Graph is treated as undirected, every bridge is listed once as pair
+// of nodes with node inserted earlier first, bridges are sorted by insertion order
+// https://en.wikipedia.org/wiki/Bridge_(graph_theory)
+func (g *Graph[K, V, W]) Bridges() [][2]K {
+	var bridges [][2]int
+	g.lowpoints(func(parent, n int) {
+		bridges = append(bridges, [2]int{min(parent, n), max(parent, n)})
+	}, nil)
+	slices.SortFunc(bridges, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+		return cmp.Compare(a[1], b[1])
+	})
+	var ret [][2]K
+	for _, b := range bridges {
+		ret = append(ret, [2]K{g.key(b[0]), g.key(b[1])})
+	}
+	return ret
+}
+
+// ArticulationPoints returns nodes whose removal increases number of connected components
+// Graph is treated as undirected, nodes are sorted by insertion order
+// https://en.wikipedia.org/wiki/Biconnected_component
+func (g *Graph[K, V, W]) ArticulationPoints() []K {
+	var points []int
+	g.lowpoints(nil, func(n int) {
+		points = append(points, n)
+	})
+	if points == nil {
+		return nil
+	}
+	slices.Sort(points)
+	return g.keys(points)
+}
+
+// lowpoints runs depth-first search treating graph as undirected,
+// calling bridge for every bridge found and cut for every articulation point
+// Low point of node is lowest discovery time reachable from its subtree
+// using at most one edge not in search tree
+func (g *Graph[K, V, W]) lowpoints(bridge func(i, j int), cut func(n int)) {
+	var (
+		disc    = make([]int, len(g.nodes)) // discovery order starting from 1, 0 if unvisited
+		low     = make([]int, len(g.nodes))
+		counter int
+	)
+
+	var visit func(n, parent int)
+	visit = func(n, parent int) {
+		counter++
+		disc[n], low[n] = counter, counter
+		var (
+			children int
+			isCut    bool
+		)
+		for _, j := range g.undirectedNeighbours(n) {
+			if j == n || j == parent {
+				continue
+			}
+			if disc[j] != 0 {
+				low[n] = min(low[n], disc[j])
+				continue
+			}
+			children++
+			visit(j, n)
+			low[n] = min(low[n], low[j])
+			if low[j] > disc[n] && bridge != nil {
+				bridge(n, j)
+			}
+			if low[j] >= disc[n] && parent != -1 {
+				isCut = true
+			}
+		}
+		// root of search tree is cut only if it has several subtrees
+		if parent == -1 && children > 1 {
+			isCut = true
+		}
+		if isCut && cut != nil {
+			cut(n)
+		}
+	}
+
+	for n := range g.nodes {
+		if g.exists(n) && disc[n] == 0 {
+			visit(n, -1)
+		}
+	}
+}
+
+// undirectedNeighbours returns sorted slots connected to slot n by edge in any direction
+func (g *Graph[K, V, W]) undirectedNeighbours(n int) []int {
+	var (
+		out = g.nodes[n].out
+		in  = g.nodes[n].in
+		ret = make([]int, 0, len(out)+len(in))
+	)
+	for len(out) > 0 || len(in) > 0 {
+		switch {
+		case len(in) == 0 || (len(out) > 0 && out[0].to < in[0]):
+			ret, out = append(ret, out[0].to), out[1:]
+		case len(out) == 0 || in[0] < out[0].to:
+			ret, in = append(ret, in[0]), in[1:]
+		default:
+			ret, out, in = append(ret, in[0]), out[1:], in[1:]
+		}
+	}
+	return ret
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sccTestEdges
+//
+//	0 -> 1 -> 3 <-> 4 -> 5 -> 6 <-> 7
+//	^   /    ^
+//	 \ v    /
+//	  2 ---´
+var sccTestEdges = []struct{ i, j, w int }{
+	{0, 1, 1},
+	{1, 2, 1},
+	{2, 0, 1},
+	{1, 3, 2},
+	{2, 3, 5},
+	{3, 4, 1},
+	{4, 3, 1},
+	{4, 5, 1},
+	{5, 6, 1},
+	{6, 7, 1},
+	{7, 6, 1},
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	g := buildGraph(make([]struct{}, 8), sccTestEdges)
+
+	for name, c := range map[string]*Components[int]{
+		"tarjan":   g.StronglyConnectedComponents(),
+		"kosaraju": g.Kosaraju(),
+	} {
+		assert.Equal(t, 4, c.Count(), name)
+		assert.Equal(t, map[int]int{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 5: 2, 6: 3, 7: 3}, c.Membership, name)
+		assert.Equal(t, [][]int{{0, 1, 2}, {3, 4}, {5}, {6, 7}}, c.Groups, name)
+	}
+
+	g.RemoveNode(5)
+	for name, c := range map[string]*Components[int]{
+		"tarjan":   g.StronglyConnectedComponents(),
+		"kosaraju": g.Kosaraju(),
+	} {
+		assert.Equal(t, map[int]int{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 6: 2, 7: 2}, c.Membership, name)
+		assert.Equal(t, [][]int{{0, 1, 2}, {3, 4}, {6, 7}}, c.Groups, name)
+	}
+
+	c := NewGraph[int, int, int](Directed).StronglyConnectedComponents()
+	assert.Equal(t, 0, c.Count())
+}
+
+func TestGraph_Condensation(t *testing.T) {
+	g := buildGraph(make([]struct{}, 8), sccTestEdges)
+
+	cg, c := g.Condensation()
+	assert.Equal(t, 4, c.Count())
+	assert.Equal(t, Directed, cg.Mode())
+	assert.Equal(t, []int{0, 1, 2, 3}, cg.Nodes())
+	for i, group := range c.Groups {
+		v, err := cg.Value(i)
+		assert.NoError(t, err)
+		assert.Equal(t, group, v)
+	}
+	var edges []Edge[int, int]
+	for _, n := range cg.Nodes() {
+		edges = append(edges, cg.Edges(n)...)
+	}
+	assert.Equal(t, []Edge[int, int]{
+		{From: 0, To: 1, Weight: 2},
+		{From: 1, To: 2, Weight: 1},
+		{From: 2, To: 3, Weight: 1},
+	}, edges)
+
+	order, err := cg.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, order)
+}
+
+// cutTestEdges, graph is treated as undirected
+//
+//	0           4
+//	| \       / |
+//	|  1 - 3    |  - 6    7
+//	| /       \ |
+//	2           5
+var cutTestEdges = []struct{ i, j, w int }{
+	{0, 1, 1},
+	{1, 2, 1},
+	{2, 0, 1},
+	{1, 3, 1},
+	{3, 4, 1},
+	{5, 4, 1},
+	{4, 5, 1},
+	{3, 5, 1},
+	{6, 5, 1},
+}
+
+func TestGraph_Bridges(t *testing.T) {
+	g := buildGraph(make([]struct{}, 8), cutTestEdges)
+	assert.Equal(t, [][2]int{{1, 3}, {5, 6}}, g.Bridges())
+	assert.Equal(t, []int{1, 3, 5}, g.ArticulationPoints())
+
+	// closing loop removes bridge, but 3 still separates both triangles
+	g.CreateEdge(2, 3, 1)
+	assert.Equal(t, [][2]int{{5, 6}}, g.Bridges())
+	assert.Equal(t, []int{3, 5}, g.ArticulationPoints())
+
+	g.RemoveNode(6)
+	assert.Empty(t, g.Bridges())
+	assert.Equal(t, []int{3}, g.ArticulationPoints())
+
+	// root of search tree with several subtrees
+	g = buildGraph(make([]struct{}, 3), []struct{ i, j, w int }{{0, 1, 1}, {0, 2, 1}})
+	assert.Equal(t, [][2]int{{0, 1}, {0, 2}}, g.Bridges())
+	assert.Equal(t, []int{0}, g.ArticulationPoints())
+}
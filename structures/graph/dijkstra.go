@@ -0,0 +1,236 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/hasansino/gobasics/structures/heap"
+)
+
+// ErrNegativeWeight is returned by algorithms which require non-negative weights
+var ErrNegativeWeight = errors.New("graph has negative edge weight")
+
+// ShortestPaths is result of single-source shortest paths search
+type ShortestPaths[K comparable, W Number] struct {
+	// Source node of the search
+	Source K
+	// Distances from source to every reachable node,
+	// unreachable nodes are absent
+	Distances map[K]W
+	// Predecessors of every reachable node on shortest path from source,
+	// source itself is absent
+	Predecessors map[K]K
+	// Expanded is number of nodes taken from priority queue and expanded
+	Expanded int
+}
+
+// PathTo returns nodes on shortest path from source to target
+// Returns nil if target is unreachable
+func (sp *ShortestPaths[K, W]) PathTo(target K) []K {
+	if _, ok := sp.Distances[target]; !ok {
+		return nil
+	}
+	path := []K{target}
+	for n := target; n != sp.Source; {
+		n = sp.Predecessors[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// search is state of single-source shortest paths search over node slots
+type search[W Number] struct {
+	source   int
+	dist     []W
+	reached  []bool
+	pred     []int // -1 for source and unreached nodes
+	expanded int
+}
+
+// newSearch initializes search from source in graph of n slots
+func newSearch[W Number](source, n int) *search[W] {
+	s := &search[W]{
+		source:  source,
+		dist:    make([]W, n),
+		reached: make([]bool, n),
+		pred:    make([]int, n),
+	}
+	for j := range s.pred {
+		s.pred[j] = -1
+	}
+	s.reached[source] = true
+	return s
+}
+
+// path from source to reached slot j
+func (s *search[W]) path(j int) []int {
+	path := []int{j}
+	for ; j != s.source; j = s.pred[j] {
+		path = append(path, s.pred[j])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// shortestPaths converts search over slots to result keyed by nodes
+func (g *Graph[K, V, W]) shortestPaths(s *search[W]) *ShortestPaths[K, W] {
+	sp := &ShortestPaths[K, W]{
+		Source:       g.key(s.source),
+		Distances:    make(map[K]W),
+		Predecessors: make(map[K]K),
+		Expanded:     s.expanded,
+	}
+	for j, ok := range s.reached {
+		if !ok || !g.exists(j) {
+			continue
+		}
+		sp.Distances[g.key(j)] = s.dist[j]
+		if s.pred[j] != -1 {
+			sp.Predecessors[g.key(j)] = g.key(s.pred[j])
+		}
+	}
+	return sp
+}
+
+// Dijkstra computes shortest paths from node to every other node
+// Priority queue makes it O((V+E) log V) instead of O(V^2) linear scan
+// Returns ErrNegativeWeight if graph has negative edges, use BellmanFord instead
+// https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm
+func (g *Graph[K, V, W]) Dijkstra(from K) (*ShortestPaths[K, W], error) {
+	i, err := g.slot(from)
+	if err != nil {
+		return nil, err
+	}
+	if g.hasNegativeWeights() {
+		return nil, ErrNegativeWeight
+	}
+	s, _ := g.dijkstra(newDijkstraQueue[W](len(g.nodes)), i, g.weight, nil)
+	return g.shortestPaths(s), nil
+}
+
+// DijkstraTo computes shortest path from one node to another
+// Search stops as soon as target is reached, so distances and predecessors
+// are known only for nodes closer to source than target
+func (g *Graph[K, V, W]) DijkstraTo(from, to K) (*ShortestPaths[K, W], error) {
+	i, err := g.slot(from)
+	if err != nil {
+		return nil, err
+	}
+	j, err := g.slot(to)
+	if err != nil {
+		return nil, err
+	}
+	if g.hasNegativeWeights() {
+		return nil, ErrNegativeWeight
+	}
+	s, _ := g.dijkstra(newDijkstraQueue[W](len(g.nodes)), i, g.weight, func(n int) bool { return n == j })
+	return g.shortestPaths(s), nil
+}
+
+// dijkstraItem is priority queue entry
+type dijkstraItem[W Number] struct {
+	node int
+	dist W
+}
+
+// dijkstraCompare orders queue entries by distance, then by node slot
+// so nodes with equal distance are settled in deterministic order
+func dijkstraCompare[W Number](a, b dijkstraItem[W]) int {
+	if a.dist != b.dist {
+		return cmp.Compare(a.dist, b.dist)
+	}
+	return cmp.Compare(a.node, b.node)
+}
+
+// dijkstraQueue is priority queue of nodes ordered by distance from source
+type dijkstraQueue[W Number] interface {
+	// Push queues node or decreases distance of already queued node
+	Push(item dijkstraItem[W])
+	// Pop removes node closest to source, false if queue is empty
+	Pop() (dijkstraItem[W], bool)
+}
+
+// indexedQueue is dijkstraQueue doing decrease-key on indexed heap
+type indexedQueue[W Number] struct {
+	heap   *heap.IndexedHeap[dijkstraItem[W]]
+	queued []*heap.Handle[dijkstraItem[W]] // handle of every queued node
+}
+
+// newDijkstraQueue creates default queue for graph of n slots
+func newDijkstraQueue[W Number](n int) dijkstraQueue[W] {
+	return &indexedQueue[W]{
+		heap:   heap.NewIndexedHeap(dijkstraCompare[W]),
+		queued: make([]*heap.Handle[dijkstraItem[W]], n),
+	}
+}
+
+func (q *indexedQueue[W]) Push(item dijkstraItem[W]) {
+	if hd := q.queued[item.node]; q.heap.Contains(hd) {
+		q.heap.Update(hd, item)
+		return
+	}
+	q.queued[item.node] = q.heap.Push(item)
+}
+
+func (q *indexedQueue[W]) Pop() (dijkstraItem[W], bool) {
+	return q.heap.Pop()
+}
+
+// dijkstra runs search from slot until stop returns true for settled node
+// Arc weights are taken from weight function and must be non-negative
+// Returns search state and slot search stopped at, or -1 if it did not stop
+// Every node is pushed to queue again when shorter path to it is found,
+// so queue has to decrease its priority
+func (g *Graph[K, V, W]) dijkstra(pq dijkstraQueue[W], from int, weight func(i int, a arc[W]) W, stop func(n int) bool) (*search[W], int) {
+	var (
+		s       = newSearch[W](from, len(g.nodes))
+		visited = make([]bool, len(g.nodes))
+	)
+
+	pq.Push(dijkstraItem[W]{node: from})
+	for {
+		item, ok := pq.Pop()
+		if !ok {
+			return s, -1
+		}
+		visited[item.node] = true
+		if stop != nil && stop(item.node) {
+			return s, item.node
+		}
+		s.expanded++
+		for _, a := range g.nodes[item.node].out {
+			if visited[a.to] {
+				continue
+			}
+			d := item.dist + weight(item.node, a)
+			if s.reached[a.to] && d >= s.dist[a.to] {
+				continue
+			}
+			s.dist[a.to], s.reached[a.to] = d, true
+			s.pred[a.to] = item.node
+			pq.Push(dijkstraItem[W]{node: a.to, dist: d})
+		}
+	}
+}
+
+// weight of arc as it is stored in graph
+func (g *Graph[K, V, W]) weight(_ int, a arc[W]) W {
+	return a.weight
+}
+
+// hasNegativeWeights tests if any edge of graph has negative weight
+func (g *Graph[K, V, W]) hasNegativeWeights() bool {
+	for _, n := range g.nodes {
+		if n == nil {
+			continue
+		}
+		for _, a := range n.out {
+			if a.weight < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/hasansino/gobasics/structures/heap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_Dijkstra(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+
+	sp, err := g.Dijkstra(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sp.Source)
+	assert.Equal(t, map[int]int{0: 0, 1: 2, 2: 9, 3: 8, 4: 10, 5: 4, 6: 6}, sp.Distances)
+	assert.Equal(t, map[int]int{1: 0, 2: 0, 3: 6, 4: 3, 5: 1, 6: 5}, sp.Predecessors)
+	assert.Equal(t, []int{0}, sp.PathTo(0))
+	assert.Equal(t, []int{0, 1, 5, 6, 3, 4}, sp.PathTo(4))
+	assert.Equal(t, []int{0, 2}, sp.PathTo(2))
+	assert.Nil(t, sp.PathTo(-1))
+	assert.Nil(t, sp.PathTo(len(testNodes)))
+
+	sp, err = g.Dijkstra(3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 8, 1: 6, 2: 11, 3: 0, 4: 2, 5: 4, 6: 2}, sp.Distances)
+	assert.Equal(t, []int{3, 4, 2}, sp.PathTo(2))
+
+	_, err = g.Dijkstra(len(testNodes))
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_Dijkstra_Unreachable(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+	g.RemoveNode(6)
+	g.InsertNode(7, 0)
+
+	sp, err := g.Dijkstra(0)
+	assert.NoError(t, err)
+	assert.NotContains(t, sp.Distances, 7)
+	assert.NotContains(t, sp.Predecessors, 7)
+	assert.Nil(t, sp.PathTo(7))
+	assert.Nil(t, sp.PathTo(6))
+	assert.Equal(t, []int{0, 1, 3}, sp.PathTo(3))
+
+	dist, err := g.DijkstrasShortestDistances(0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 0, 1: 2, 2: 9, 3: 11, 4: 13, 5: 4}, dist)
+	_, err = g.DijkstrasShortestDistances(6)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_DijkstraTo(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+
+	sp, err := g.DijkstraTo(0, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 5}, sp.PathTo(5))
+	assert.Equal(t, 4, sp.Distances[5])
+	// search stopped before reaching farther nodes
+	assert.NotContains(t, sp.Distances, 4)
+
+	sp, err = g.DijkstraTo(0, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 5, 6, 3, 4}, sp.PathTo(4))
+
+	_, err = g.DijkstraTo(0, -1)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	_, err = g.DijkstraTo(-1, 0)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_ShortestPath_Weighted(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+	path, dist, err := g.ShortestPath(0, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 5, 6, 3, 4}, path)
+	assert.Equal(t, 10, dist)
+	path, dist, _ = g.ShortestPath(0, 2)
+	assert.Equal(t, []int{0, 2}, path)
+	assert.Equal(t, 9, dist)
+	_, _, err = g.ShortestPath(0, 999)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+// fibonacciQueue is dijkstraQueue doing decrease-key on fibonacci heap
+type fibonacciQueue struct {
+	heap   *heap.FibonacciHeap[dijkstraItem[int]]
+	queued []*heap.FibonacciNode[dijkstraItem[int]]
+}
+
+func (q *fibonacciQueue) Push(item dijkstraItem[int]) {
+	// dijkstra never pushes node which was popped
+	if n := q.queued[item.node]; n != nil {
+		q.heap.DecreaseKey(n, item)
+		return
+	}
+	q.queued[item.node] = q.heap.Insert(item)
+}
+
+func (q *fibonacciQueue) Pop() (dijkstraItem[int], bool) {
+	return q.heap.Pop()
+}
+
+// lazyQueue is dijkstraQueue on heap without decrease-key,
+// it holds duplicate entries of node and skips stale ones
+type lazyQueue struct {
+	heap   heap.PriorityQueue[dijkstraItem[int]]
+	popped []bool
+}
+
+func (q *lazyQueue) Push(item dijkstraItem[int]) {
+	q.heap.Push(item)
+}
+
+func (q *lazyQueue) Pop() (dijkstraItem[int], bool) {
+	for {
+		item, ok := q.heap.Pop()
+		if !ok || !q.popped[item.node] {
+			if ok {
+				q.popped[item.node] = true
+			}
+			return item, ok
+		}
+	}
+}
+
+// dijkstraQueues are heap variants dijkstra is benchmarked on
+var dijkstraQueues = map[string]func(n int) dijkstraQueue[int]{
+	"indexed": newDijkstraQueue[int],
+	"fibonacci": func(n int) dijkstraQueue[int] {
+		return &fibonacciQueue{
+			heap:   heap.NewFibonacciHeap(dijkstraCompare[int]),
+			queued: make([]*heap.FibonacciNode[dijkstraItem[int]], n),
+		}
+	},
+	"binary": func(n int) dijkstraQueue[int] {
+		return &lazyQueue{heap: heap.NewBinaryHeap(dijkstraCompare[int]), popped: make([]bool, n)}
+	},
+	"4-ary": func(n int) dijkstraQueue[int] {
+		return &lazyQueue{heap: heap.NewDaryHeap(4, dijkstraCompare[int]), popped: make([]bool, n)}
+	},
+	"pairing": func(n int) dijkstraQueue[int] {
+		return &lazyQueue{heap: heap.NewPairingHeap(dijkstraCompare[int]), popped: make([]bool, n)}
+	},
+	"binomial": func(n int) dijkstraQueue[int] {
+		return &lazyQueue{heap: heap.NewBinomialHeap(dijkstraCompare[int]), popped: make([]bool, n)}
+	},
+}
+
+// randomGraph with n nodes where every edge exists with probability p
+func randomGraph(n int, p float64) *Graph[int, struct{}, int] {
+	var (
+		g   = NewGraph[int, struct{}, int](Directed)
+		rnd = rand.New(rand.NewPCG(uint64(n), 1))
+	)
+	for i := 0; i < n; i++ {
+		g.InsertNode(i, struct{}{})
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && rnd.Float64() < p {
+				_ = g.CreateEdge(i, j, 1+rnd.IntN(100))
+			}
+		}
+	}
+	return g
+}
+
+func TestGraph_Dijkstra_Heaps(t *testing.T) {
+	g := randomGraph(200, 0.05)
+	expected, err := g.Dijkstra(0)
+	assert.NoError(t, err)
+	for name, newQueue := range dijkstraQueues {
+		s, target := g.dijkstra(newQueue(len(g.nodes)), 0, g.weight, nil)
+		assert.Equal(t, expected, g.shortestPaths(s), name)
+		assert.Equal(t, -1, target, name)
+
+		s, target = g.dijkstra(newQueue(len(g.nodes)), 0, g.weight, func(n int) bool { return n == 100 })
+		assert.Equal(t, expected.Distances[100], s.dist[100], name)
+		assert.Equal(t, 100, target, name)
+	}
+}
+
+func BenchmarkGraph_Dijkstra(b *testing.B) {
+	for _, p := range []float64{0.01, 0.5} {
+		g := randomGraph(1<<10, p)
+		for name, newQueue := range dijkstraQueues {
+			b.Run(fmt.Sprintf("%s/%v", name, p), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					// skip negative weights check to compare queues only
+					g.dijkstra(newQueue(len(g.nodes)), 0, g.weight, nil)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DOT is graph description language of Graphviz
+// Directed graph is written as digraph and undirected as graph,
+// node labels hold values and edge labels hold weights
+//
+//	digraph {
+//		0 [label="4"];
+//		1;
+//		0 -> 1 [label="2"];
+//	}
+//
+// Node identifiers which are not DOT identifiers or numerals are quoted
+// Reader supports subset of the language sufficient for hand-written fixtures:
+// graph and digraph, node and edge statements, edge chains, attribute lists
+// and comments; subgraphs are not supported
+// Edge weight is taken from weight attribute, then from label, defaulting to 1
+// https://graphviz.org/doc/info/lang.html
+
+// WriteDOT writes graph in DOT format
+func (g *Graph[K, V, W]) WriteDOT(w io.Writer) error {
+	var (
+		bw       = bufio.NewWriter(w)
+		kind, op = "digraph", "->"
+	)
+	if g.mode == Undirected {
+		kind, op = "graph", "--"
+	}
+	fmt.Fprintf(bw, "%s {\n", kind)
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		id := dotID(fmt.Sprint(g.key(i)))
+		if label, ok := g.nodeLabel(i); ok {
+			fmt.Fprintf(bw, "\t%s [label=%s];\n", id, dotQuote(label))
+		} else {
+			fmt.Fprintf(bw, "\t%s;\n", id)
+		}
+	}
+	for _, e := range g.edgeList() {
+		fmt.Fprintf(bw, "\t%s %s %s [label=\"%v\"];\n",
+			dotID(fmt.Sprint(g.key(e.from))), op, dotID(fmt.Sprint(g.key(e.to))), e.weight)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// ReadDOT reads graph in DOT format
+func ReadDOT[K comparable, V any, W Number](r io.Reader, decoder Decoder[K, V]) (*Graph[K, V, W], error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser[K, V, W]{
+		lex: dotLexer{src: string(data), line: 1},
+		b:   newGraphBuilder[K, V, W](decoder),
+	}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", p.lex.line, err)
+	}
+	return p.b.build(), nil
+}
+
+// dotKeywords can't be used as unquoted identifiers
+var dotKeywords = []string{"node", "edge", "graph", "digraph", "subgraph", "strict"}
+
+// dotID returns node identifier as is if it is DOT identifier or numeral,
+// otherwise it is quoted
+func dotID(id string) string {
+	for _, k := range dotKeywords {
+		if strings.EqualFold(id, k) {
+			return dotQuote(id)
+		}
+	}
+	if isDOTIdentifier(id) || isDOTNumeral(id) {
+		return id
+	}
+	return dotQuote(id)
+}
+
+// isDOTIdentifier tests if s is letters, digits and underscores not starting with digit
+func isDOTIdentifier(s string) bool {
+	if s == "" || ('0' <= s[0] && s[0] <= '9') {
+		return false
+	}
+	for j := 0; j < len(s); j++ {
+		if c := s[j]; c == '.' || !isDOTIDByte(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDOTNumeral tests if s is optionally signed decimal number
+func isDOTNumeral(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	var digits, dots int
+	for j := 0; j < len(s); j++ {
+		switch c := s[j]; {
+		case c == '.':
+			dots++
+		case '0' <= c && c <= '9':
+			digits++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// dotQuote returns label as DOT quoted string
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// dotUnquote reverses dotQuote, unknown escapes are kept as is
+func dotUnquote(s string) string {
+	r := strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", "\\\n", "")
+	return r.Replace(s)
+}
+
+// dotToken is lexical token of DOT language
+type dotToken struct {
+	text   string
+	quoted bool // text is quoted string, never a keyword or punctuation
+}
+
+// dotLexer splits DOT source into tokens
+type dotLexer struct {
+	src    string
+	pos    int
+	line   int
+	peeked *dotToken
+}
+
+// peek returns next token without consuming it
+// Empty token means end of input
+func (l *dotLexer) peek() (dotToken, error) {
+	if l.peeked == nil {
+		t, err := l.scan()
+		if err != nil {
+			return dotToken{}, err
+		}
+		l.peeked = &t
+	}
+	return *l.peeked, nil
+}
+
+// next consumes next token
+func (l *dotLexer) next() (dotToken, error) {
+	t, err := l.peek()
+	l.peeked = nil
+	return t, err
+}
+
+func (l *dotLexer) scan() (dotToken, error) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return dotToken{}, nil
+	}
+	start := l.pos
+	switch c := l.src[l.pos]; {
+	case strings.IndexByte("{}[];,=:", c) != -1:
+		l.pos++
+	case strings.HasPrefix(l.src[l.pos:], "->"), strings.HasPrefix(l.src[l.pos:], "--"):
+		l.pos += 2
+	case c == '"':
+		for l.pos++; l.pos < len(l.src) && l.src[l.pos] != '"'; l.pos++ {
+			switch l.src[l.pos] {
+			case '\\':
+				l.pos++
+				if l.pos < len(l.src) && l.src[l.pos] == '\n' {
+					l.line++
+				}
+			case '\n':
+				l.line++
+			}
+		}
+		if l.pos >= len(l.src) {
+			return dotToken{}, fmt.Errorf("%w: unterminated string", ErrInvalidFormat)
+		}
+		l.pos++
+		return dotToken{text: dotUnquote(l.src[start+1 : l.pos-1]), quoted: true}, nil
+	case c == '<':
+		return dotToken{}, fmt.Errorf("%w: html strings are not supported", ErrInvalidFormat)
+	default:
+		if c == '-' {
+			l.pos++ // sign of numeral
+		}
+		for l.pos < len(l.src) && isDOTIDByte(l.src[l.pos]) {
+			l.pos++
+		}
+		if l.pos == start {
+			return dotToken{}, fmt.Errorf("%w: unexpected character %q", ErrInvalidFormat, c)
+		}
+	}
+	return dotToken{text: l.src[start:l.pos]}, nil
+}
+
+// skipSpace skips whitespace and comments
+func (l *dotLexer) skipSpace() {
+	for l.pos < len(l.src) {
+		rest := l.src[l.pos:]
+		switch {
+		case rest[0] == '\n':
+			l.line++
+			l.pos++
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r':
+			l.pos++
+		case strings.HasPrefix(rest, "//"), rest[0] == '#':
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			l.pos += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest, "*/")
+			if end == -1 {
+				end = len(rest) - 2
+			}
+			l.line += strings.Count(rest[:end], "\n")
+			l.pos += end + 2
+		default:
+			return
+		}
+	}
+}
+
+// isDOTIDByte tests if byte can be part of unquoted identifier or numeral
+func isDOTIDByte(c byte) bool {
+	return c == '_' || c == '.' || c >= 0x80 ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// dotParser builds graph from DOT tokens
+type dotParser[K comparable, V any, W Number] struct {
+	lex      dotLexer
+	b        *graphBuilder[K, V, W]
+	directed bool
+}
+
+// keyword tests if token is given keyword, keywords are case-insensitive
+func (t dotToken) keyword(k string) bool {
+	return !t.quoted && strings.EqualFold(t.text, k)
+}
+
+// punct tests if token is given punctuation
+func (t dotToken) punct(p string) bool {
+	return !t.quoted && t.text == p
+}
+
+// expect consumes token which has to be given punctuation
+func (p *dotParser[K, V, W]) expect(punct string) error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	if !t.punct(punct) {
+		return fmt.Errorf("%w: expected %q, got %q", ErrInvalidFormat, punct, t.text)
+	}
+	return nil
+}
+
+func (p *dotParser[K, V, W]) parse() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	if t.keyword("strict") {
+		if t, err = p.lex.next(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case t.keyword("digraph"):
+		p.directed = true
+	case t.keyword("graph"):
+		p.b.mode = Undirected
+	default:
+		return fmt.Errorf("%w: expected graph or digraph, got %q", ErrInvalidFormat, t.text)
+	}
+	// optional graph name
+	if t, err = p.lex.peek(); err != nil {
+		return err
+	}
+	if !t.punct("{") {
+		_, _ = p.lex.next()
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		t, err := p.lex.peek()
+		if err != nil {
+			return err
+		}
+		switch {
+		case t.text == "" && !t.quoted:
+			return fmt.Errorf("%w: unexpected end of input", ErrInvalidFormat)
+		case t.punct("}"):
+			_, _ = p.lex.next()
+			if t, err = p.lex.next(); err != nil || t.text != "" || t.quoted {
+				return fmt.Errorf("%w: unexpected %q after graph", ErrInvalidFormat, t.text)
+			}
+			return nil
+		case t.punct(";"):
+			_, _ = p.lex.next()
+		default:
+			if err := p.statement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *dotParser[K, V, W]) statement() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	switch {
+	case t.keyword("subgraph"), t.punct("{"):
+		return fmt.Errorf("%w: subgraphs are not supported", ErrInvalidFormat)
+	case t.keyword("graph"), t.keyword("node"), t.keyword("edge"):
+		// default attributes are ignored
+		_, err := p.attributes()
+		return err
+	case !t.quoted && strings.IndexByte("{}[];,=:", t.text[0]) != -1:
+		return fmt.Errorf("%w: unexpected %q", ErrInvalidFormat, t.text)
+	}
+
+	next, err := p.lex.peek()
+	if err != nil {
+		return err
+	}
+	if next.punct("=") {
+		// graph attributes are ignored
+		_, _ = p.lex.next()
+		_, err := p.lex.next()
+		return err
+	}
+
+	nodes := []K{}
+	for {
+		n, err := p.b.node(t.text)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, n)
+		if next, err = p.lex.peek(); err != nil {
+			return err
+		}
+		if !next.punct("->") && !next.punct("--") {
+			break
+		}
+		if next.punct("->") != p.directed {
+			return fmt.Errorf("%w: edge operator %q does not match graph type", ErrInvalidFormat, next.text)
+		}
+		_, _ = p.lex.next()
+		if t, err = p.lex.next(); err != nil {
+			return err
+		}
+	}
+
+	attrs, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 1 {
+		if label, ok := attrs["label"]; ok {
+			return p.b.label(nodes[0], label)
+		}
+		return nil
+	}
+	weight, ok := attrs["weight"]
+	if !ok {
+		if weight, ok = attrs["label"]; !ok {
+			weight = "1"
+		}
+	}
+	for j := 1; j < len(nodes); j++ {
+		if err := p.b.edge(nodes[j-1], nodes[j], weight, !p.directed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attributes parses optional attribute lists
+func (p *dotParser[K, V, W]) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for {
+		t, err := p.lex.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !t.punct("[") {
+			return attrs, nil
+		}
+		_, _ = p.lex.next()
+		for {
+			key, err := p.lex.next()
+			if err != nil {
+				return nil, err
+			}
+			if key.punct("]") {
+				break
+			}
+			if key.punct(";") || key.punct(",") {
+				continue
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.lex.next()
+			if err != nil {
+				return nil, err
+			}
+			if value.text == "" && !value.quoted {
+				return nil, fmt.Errorf("%w: unexpected end of input", ErrInvalidFormat)
+			}
+			attrs[key.text] = value.text
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := buildGraph([]interface{}{4, nil, "x"}, []struct{ i, j, w int }{{0, 1, 2}, {2, 0, -1}})
+
+	var buf bytes.Buffer
+	assert.NoError(t, g.WriteDOT(&buf))
+	assert.Equal(t, `digraph {
+	0 [label="4"];
+	1;
+	2 [label="x"];
+	0 -> 1 [label="2"];
+	2 -> 0 [label="-1"];
+}
+`, buf.String())
+
+	// identifiers which are not DOT identifiers or numerals are quoted
+	ug := NewGraph[string, int, float64](Undirected)
+	for _, k := range []string{"a_1", "two words", "node", "-1.5", "2x"} {
+		ug.InsertNode(k, len(k))
+	}
+	ug.CreateEdge("a_1", "two words", 1.5)
+	ug.CreateEdge("node", "node", 2)
+
+	buf.Reset()
+	assert.NoError(t, ug.WriteDOT(&buf))
+	assert.Equal(t, `graph {
+	a_1 [label="3"];
+	"two words" [label="9"];
+	"node" [label="4"];
+	-1.5 [label="4"];
+	"2x" [label="2"];
+	a_1 -- "two words" [label="1.5"];
+	"node" -- "node" [label="2"];
+}
+`, buf.String())
+}
+
+func TestReadDOT(t *testing.T) {
+	g, err := ReadDOT[int, string, int](strings.NewReader(`
+/* build pipeline */
+strict digraph pipeline {
+	rankdir = LR; // graph attributes are ignored
+	node [shape=box]
+	0 [label="fetch"]; 1 [label="build", color=red]
+	2 [ label = "test" ]
+	# chain of edges shares attributes
+	0 -> 1 -> 2 [weight=3, label="ignored"]
+	1->3 [label=7]
+	2 -> 3
+}
+`), Decoder[int, string]{})
+	assert.NoError(t, err)
+	assert.Equal(t, Directed, g.Mode())
+	assert.Equal(t, []int{0, 1, 2, 3}, g.Nodes())
+	for k, expected := range []string{"fetch", "build", "test", ""} {
+		v, _ := g.Value(k)
+		assert.Equal(t, expected, v)
+	}
+	assert.Equal(t, []Edge[int, int]{
+		{From: 0, To: 1, Weight: 3},
+		{From: 1, To: 2, Weight: 3},
+		{From: 1, To: 3, Weight: 7},
+		{From: 2, To: 3, Weight: 1},
+	}, allEdges(g))
+
+	// graph is undirected
+	g, err = ReadDOT[int, string, int](strings.NewReader(`graph { 0 -- 1 [weight=2]; 1 -- 1 }`), Decoder[int, string]{})
+	assert.NoError(t, err)
+	assert.Equal(t, Undirected, g.Mode())
+	assert.Equal(t, 2, g.EdgeCount())
+	assert.Equal(t, []Edge[int, int]{
+		{From: 0, To: 1, Weight: 2},
+		{From: 1, To: 0, Weight: 2},
+		{From: 1, To: 1, Weight: 1},
+	}, allEdges(g))
+
+	for _, invalid := range []string{
+		``,
+		`digraph`,
+		`digraph {`,
+		`tree { 0 }`,
+		`digraph { 0 -- 1 }`,
+		`graph { 0 -> 1 }`,
+		`digraph { a -> b }`,
+		`digraph { 0 -> }`,
+		`digraph { 0 -> 1 [weight=x] }`,
+		`digraph { 0 [label="unterminated] }`,
+		`digraph { 0 [label=<b>html</b>] }`,
+		`digraph { subgraph { 0 } }`,
+		`digraph { 0:port }`,
+		`digraph { 0 } 1`,
+	} {
+		_, err := ReadDOT[int, string, int](strings.NewReader(invalid), Decoder[int, string]{})
+		assert.ErrorIs(t, err, ErrInvalidFormat, invalid)
+	}
+}
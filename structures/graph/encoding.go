@@ -0,0 +1,295 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Graphs are encoded in text formats, nodes are identified by their keys
+// and node values are written as labels, both using fmt.Sprint,
+// so keys and values implementing fmt.Stringer are written with their String method
+// Node holding zero value is written without label
+// Nodes are written in insertion order and decoded graph keeps it
+
+// ErrInvalidFormat is returned when decoded data is malformed
+var ErrInvalidFormat = errors.New("invalid graph format")
+
+// Decoder converts node identifiers and labels back to keys and values
+// Nil function parses text according to kind of decoded type: strings
+// are kept as is, numbers and booleans are parsed, interface types get string
+type Decoder[K comparable, V any] struct {
+	Key   func(id string) (K, error)
+	Value func(label string) (V, error)
+}
+
+// parseText parses text as value of type T according to its kind
+func parseText[T any](s string) (T, error) {
+	var (
+		v   T
+		rv  = reflect.ValueOf(&v).Elem()
+		err error
+	)
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, rv.Type().Bits()); err == nil {
+			rv.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, rv.Type().Bits()); err == nil {
+			rv.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, rv.Type().Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			rv.SetBool(b)
+		}
+	case reflect.Interface:
+		if !reflect.TypeOf(s).AssignableTo(rv.Type()) {
+			return v, fmt.Errorf("string is not assignable to %s", rv.Type())
+		}
+		rv.Set(reflect.ValueOf(s))
+	default:
+		return v, fmt.Errorf("no default decoding for %s", rv.Type())
+	}
+	return v, err
+}
+
+// nodeLabel returns label of node in slot i, false if node holds zero value
+func (g *Graph[K, V, W]) nodeLabel(i int) (string, bool) {
+	if reflect.ValueOf(&g.nodes[i].value).Elem().IsZero() {
+		return "", false
+	}
+	return fmt.Sprint(g.nodes[i].value), true
+}
+
+// builtEdge is edge collected while decoding
+type builtEdge[K comparable, W Number] struct {
+	Edge[K, W]
+	undirected bool
+}
+
+// graphBuilder collects nodes and edges while decoding
+// and builds graph once all of them are known
+type graphBuilder[K comparable, V any, W Number] struct {
+	decoder Decoder[K, V]
+	mode    Mode
+	keys    []K // in order of declaration
+	values  map[K]V
+	edges   []builtEdge[K, W]
+}
+
+func newGraphBuilder[K comparable, V any, W Number](decoder Decoder[K, V]) *graphBuilder[K, V, W] {
+	if decoder.Key == nil {
+		decoder.Key = parseText[K]
+	}
+	if decoder.Value == nil {
+		decoder.Value = parseText[V]
+	}
+	return &graphBuilder[K, V, W]{decoder: decoder, values: make(map[K]V)}
+}
+
+// node parses node identifier and declares node
+func (b *graphBuilder[K, V, W]) node(id string) (K, error) {
+	k, err := b.decoder.Key(id)
+	if err != nil {
+		return k, fmt.Errorf("%w: node id %q: %w", ErrInvalidFormat, id, err)
+	}
+	if _, ok := b.values[k]; !ok {
+		var zero V
+		b.keys = append(b.keys, k)
+		b.values[k] = zero
+	}
+	return k, nil
+}
+
+// label sets value of node k decoding its label
+func (b *graphBuilder[K, V, W]) label(k K, label string) error {
+	v, err := b.decoder.Value(label)
+	if err != nil {
+		return fmt.Errorf("%w: node %v: %w", ErrInvalidFormat, k, err)
+	}
+	b.values[k] = v
+	return nil
+}
+
+// edge between declared nodes
+func (b *graphBuilder[K, V, W]) edge(from, to K, weight string, undirected bool) error {
+	w, err := parseText[W](weight)
+	if err != nil {
+		return fmt.Errorf("%w: edge %v -> %v has invalid weight %q", ErrInvalidFormat, from, to, weight)
+	}
+	b.edges = append(b.edges, builtEdge[K, W]{Edge: Edge[K, W]{From: from, To: to, Weight: w}, undirected: undirected})
+	return nil
+}
+
+// build graph of declared nodes in order of declaration
+// Graph is undirected if it was declared undirected and every edge is undirected,
+// otherwise undirected edges are created in both directions
+func (b *graphBuilder[K, V, W]) build() *Graph[K, V, W] {
+	mode := b.mode
+	for _, e := range b.edges {
+		if !e.undirected {
+			mode = Directed
+		}
+	}
+	g := NewGraph[K, V, W](mode)
+	for _, k := range b.keys {
+		_ = g.InsertNode(k, b.values[k]) // keys are unique
+	}
+	for _, e := range b.edges {
+		_ = g.CreateEdge(e.From, e.To, e.Weight) // both nodes were declared
+		if mode == Directed && e.undirected {
+			_ = g.CreateEdge(e.To, e.From, e.Weight)
+		}
+	}
+	return g
+}
+
+// Edge list format is line based, blank lines and lines starting with # are ignored
+// Identifiers containing spaces or quotes are written as quoted Go strings
+//
+//	# graph is undirected, has to precede edges
+//	undirected
+//	# node with label, label is quoted Go string
+//	0 "value"
+//	# node without label
+//	1
+//	# edge from to weight
+//	0 1 2
+//	# edge with weight 1
+//	1 0
+
+// edgeListKeyword declares undirected graph in edge list format
+const edgeListKeyword = "undirected"
+
+// edgeListID returns node identifier, quoted if it can't be written as is
+func edgeListID(id string) string {
+	if id == "" || id == edgeListKeyword || id[0] == '#' ||
+		strings.ContainsFunc(id, func(r rune) bool { return r == '"' || unicode.IsSpace(r) }) {
+		return strconv.Quote(id)
+	}
+	return id
+}
+
+// WriteEdgeList writes graph in edge list format
+func (g *Graph[K, V, W]) WriteEdgeList(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if g.mode == Undirected {
+		fmt.Fprintln(bw, edgeListKeyword)
+	}
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		id := edgeListID(fmt.Sprint(g.key(i)))
+		if label, ok := g.nodeLabel(i); ok {
+			fmt.Fprintf(bw, "%s %s\n", id, strconv.Quote(label))
+		} else {
+			fmt.Fprintln(bw, id)
+		}
+	}
+	for _, e := range g.edgeList() {
+		fmt.Fprintf(bw, "%s %s %v\n", edgeListID(fmt.Sprint(g.key(e.from))), edgeListID(fmt.Sprint(g.key(e.to))), e.weight)
+	}
+	return bw.Flush()
+}
+
+// ReadEdgeList reads graph in edge list format
+// Nodes referenced by edges are created even if they are not declared
+func ReadEdgeList[K comparable, V any, W Number](r io.Reader, decoder Decoder[K, V]) (*Graph[K, V, W], error) {
+	var (
+		b       = newGraphBuilder[K, V, W](decoder)
+		scanner = bufio.NewScanner(r)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		if err := readEdgeListLine(b, scanner.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b.build(), nil
+}
+
+// edgeListToken is identifier or label of edge list line
+type edgeListToken struct {
+	text   string
+	quoted bool
+}
+
+// edgeListTokens splits line into whitespace separated tokens and quoted strings
+func edgeListTokens(line string) ([]edgeListToken, error) {
+	var tokens []edgeListToken
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if line[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid quoted string %s", ErrInvalidFormat, line)
+			}
+			text, _ := strconv.Unquote(quoted) // prefix is valid quoted string
+			tokens = append(tokens, edgeListToken{text: text, quoted: true})
+			line = line[len(quoted):]
+			continue
+		}
+		end := strings.IndexFunc(line, unicode.IsSpace)
+		if end == -1 {
+			end = len(line)
+		}
+		tokens = append(tokens, edgeListToken{text: line[:end]})
+		line = line[end:]
+	}
+	return tokens, nil
+}
+
+func readEdgeListLine[K comparable, V any, W Number](b *graphBuilder[K, V, W], line string) error {
+	if line = strings.TrimSpace(line); line == "" || line[0] == '#' {
+		return nil
+	}
+	tokens, err := edgeListTokens(line)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 1 && !tokens[0].quoted && tokens[0].text == edgeListKeyword {
+		b.mode = Undirected
+		return nil
+	}
+	if len(tokens) > 3 {
+		return fmt.Errorf("%w: unexpected %q", ErrInvalidFormat, tokens[3].text)
+	}
+	k, err := b.node(tokens[0].text)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(tokens) == 1:
+		return nil
+	case len(tokens) == 2 && tokens[1].quoted:
+		return b.label(k, tokens[1].text)
+	}
+
+	to, err := b.node(tokens[1].text)
+	if err != nil {
+		return err
+	}
+	weight := "1"
+	if len(tokens) == 3 {
+		weight = tokens[2].text
+	}
+	return b.edge(k, to, weight, b.mode == Undirected)
+}
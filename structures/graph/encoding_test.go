@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPoint is node value implementing fmt.Stringer
+type testPoint struct {
+	x, y int
+}
+
+func (p testPoint) String() string {
+	return fmt.Sprintf("(%d, %d)", p.x, p.y)
+}
+
+func decodeTestPoint(label string) (testPoint, error) {
+	var p testPoint
+	if _, err := fmt.Sscanf(label, "(%d, %d)", &p.x, &p.y); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// encodingFormat writes graph and reads it back
+type encodingFormat[K comparable, V any, W Number] struct {
+	write func(*Graph[K, V, W], io.Writer) error
+	read  func(io.Reader, Decoder[K, V]) (*Graph[K, V, W], error)
+}
+
+func encodingFormats[K comparable, V any, W Number]() map[string]encodingFormat[K, V, W] {
+	return map[string]encodingFormat[K, V, W]{
+		"edgelist": {(*Graph[K, V, W]).WriteEdgeList, ReadEdgeList[K, V, W]},
+		"dot":      {(*Graph[K, V, W]).WriteDOT, ReadDOT[K, V, W]},
+		"graphml":  {(*Graph[K, V, W]).WriteGraphML, ReadGraphML[K, V, W]},
+	}
+}
+
+func roundTrip[K comparable, V any, W Number](t *testing.T, g *Graph[K, V, W], decoder Decoder[K, V]) map[string]*Graph[K, V, W] {
+	decoded := make(map[string]*Graph[K, V, W])
+	for name, format := range encodingFormats[K, V, W]() {
+		var buf bytes.Buffer
+		assert.NoError(t, format.write(g, &buf), name)
+		g2, err := format.read(&buf, decoder)
+		if assert.NoError(t, err, name) {
+			decoded[name] = g2
+		}
+	}
+	return decoded
+}
+
+// allEdges of graph in insertion order of their nodes
+func allEdges[K comparable, V any, W Number](g *Graph[K, V, W]) []Edge[K, W] {
+	var edges []Edge[K, W]
+	for _, k := range g.Nodes() {
+		edges = append(edges, g.Edges(k)...)
+	}
+	return edges
+}
+
+// assertEqualGraphs checks that graphs have the same mode, nodes, values and edges
+func assertEqualGraphs[K comparable, V any, W Number](t *testing.T, expected, actual *Graph[K, V, W], name string) {
+	assert.Equal(t, expected.Mode(), actual.Mode(), name)
+	assert.Equal(t, expected.Nodes(), actual.Nodes(), name)
+	assert.Equal(t, expected.EdgeCount(), actual.EdgeCount(), name)
+	for _, k := range expected.Nodes() {
+		ev, _ := expected.Value(k)
+		av, _ := actual.Value(k)
+		assert.Equal(t, ev, av, name)
+		assert.Equal(t, expected.Edges(k), actual.Edges(k), name)
+	}
+}
+
+func TestGraph_Encoding_RoundTrip(t *testing.T) {
+	g := NewGraph[int, testPoint, int](Directed)
+	for k, p := range []testPoint{{0, 0}, {1, 2}, {-3, 4}, {5, -6}, {7, 8}} {
+		g.InsertNode(k*10, p)
+	}
+	g.CreateEdge(0, 10, 2)
+	g.CreateEdge(10, 0, 3)
+	g.CreateEdge(10, 40, -7)
+	g.CreateEdge(40, 40, 0)
+	g.RemoveNode(20)
+
+	for name, g2 := range roundTrip(t, g, Decoder[int, testPoint]{Value: decodeTestPoint}) {
+		assertEqualGraphs(t, g, g2, name)
+	}
+
+	// without decoder labels are kept as strings
+	readers := encodingFormats[int, any, int]()
+	for name, format := range encodingFormats[int, testPoint, int]() {
+		var buf bytes.Buffer
+		assert.NoError(t, format.write(g, &buf), name)
+		g2, err := readers[name].read(&buf, Decoder[int, any]{})
+		if assert.NoError(t, err, name) {
+			v, _ := g2.Value(10)
+			assert.Equal(t, "(1, 2)", v, name)
+			assert.Equal(t, allEdges(g), allEdges(g2), name)
+		}
+	}
+}
+
+func TestGraph_Encoding_Undirected(t *testing.T) {
+	g := newKeyedTestGraph(t, Undirected)
+	// keys which have to be quoted or escaped
+	for _, k := range []string{"two words", `q"uote`, "graph", "undirected", "#", ""} {
+		g.InsertNode(k, len(k))
+		g.CreateEdge("a", k, 0.5)
+	}
+	g.CreateEdge("graph", "graph", 1.5)
+
+	for name, g2 := range roundTrip(t, g, Decoder[string, int]{}) {
+		assertEqualGraphs(t, g, g2, name)
+	}
+}
+
+func TestGraph_Encoding_Labels(t *testing.T) {
+	g := NewGraph[int, string, int](Directed)
+	for k, v := range []string{`quoted "label"`, "back\\slash", "multi\nline", ""} {
+		g.InsertNode(k, v)
+	}
+	g.CreateEdge(0, 3, 1)
+
+	for name, g2 := range roundTrip(t, g, Decoder[int, string]{}) {
+		assertEqualGraphs(t, g, g2, name)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, g.WriteEdgeList(&buf))
+	assert.Equal(t, `0 "quoted \"label\""
+1 "back\\slash"
+2 "multi\nline"
+3
+0 3 1
+`, buf.String())
+
+	failing := Decoder[int, string]{
+		Value: func(string) (string, error) { return "", errors.New("bad label") },
+	}
+	for name, format := range encodingFormats[int, string, int]() {
+		buf.Reset()
+		assert.NoError(t, format.write(g, &buf), name)
+		_, err := format.read(&buf, failing)
+		assert.ErrorIs(t, err, ErrInvalidFormat, name)
+	}
+}
+
+func TestReadEdgeList(t *testing.T) {
+	g, err := ReadEdgeList[int, string, int](strings.NewReader(`
+# triangle with isolated node
+0 "a"
+1	"b"
+0 1 5
+1 2
+2 0 -1
+
+4
+`), Decoder[int, string]{})
+	assert.NoError(t, err)
+	assert.Equal(t, Directed, g.Mode())
+	assert.Equal(t, []int{0, 1, 2, 4}, g.Nodes())
+	v, _ := g.Value(0)
+	assert.Equal(t, "a", v)
+	v, _ = g.Value(1)
+	assert.Equal(t, "b", v)
+	v, _ = g.Value(2)
+	assert.Empty(t, v)
+	assert.Equal(t, []Edge[int, int]{
+		{From: 0, To: 1, Weight: 5},
+		{From: 1, To: 2, Weight: 1},
+		{From: 2, To: 0, Weight: -1},
+	}, allEdges(g))
+
+	// quoted second token is label, so edge to quoted node needs weight
+	sg, err := ReadEdgeList[string, string, float64](strings.NewReader(`undirected
+"x y" "label"
+"x y" "undirected" 2.5
+z "x y" 1
+`), Decoder[string, string]{})
+	assert.NoError(t, err)
+	assert.Equal(t, Undirected, sg.Mode())
+	assert.Equal(t, []string{"x y", "undirected", "z"}, sg.Nodes())
+	v, _ = sg.Value("x y")
+	assert.Equal(t, "label", v)
+	w, ok := sg.Weight("undirected", "x y")
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, w)
+	assert.True(t, sg.Adjacent("x y", "z"))
+
+	for _, invalid := range []string{
+		"a 1",
+		"0 1 2 3",
+		"0 1 x",
+		"0 1 1.5",
+		`0 "unterminated`,
+		`"unterminated 1`,
+	} {
+		_, err := ReadEdgeList[int, string, int](strings.NewReader(invalid), Decoder[int, string]{})
+		assert.ErrorIs(t, err, ErrInvalidFormat, invalid)
+	}
+}
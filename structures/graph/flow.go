@@ -0,0 +1,430 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/hasansino/gobasics/structures/queue"
+)
+
+var (
+	// ErrSourceIsSink is returned when flow source and sink are the same node
+	ErrSourceIsSink = errors.New("source and sink are the same node")
+	// ErrNotBipartite is returned when edge connects two nodes of the same side
+	ErrNotBipartite = errors.New("graph is not bipartite")
+)
+
+// MaxFlow is maximum flow from source to sink,
+// edge weights are treated as capacities
+// In undirected graph every edge can carry flow in both directions
+// https://en.wikipedia.org/wiki/Maximum_flow_problem
+type MaxFlow[K comparable, W Number] struct {
+	// Value of flow leaving source, equal to capacity of minimum cut
+	Value W
+	// Flow through every edge keyed by its nodes
+	Flow map[[2]K]W
+	// Cost of flow, sum of flow multiplied by edge cost
+	// Only MinCostMaxFlow computes it, it is 0 otherwise
+	Cost W
+	// SourceSide of minimum cut, nodes reachable from source in residual
+	// network in insertion order, remaining nodes are on sink side
+	SourceSide []K
+	// Cut is list of edges going from source side to sink side,
+	// all of them are saturated and their capacities sum up to Value
+	Cut []Edge[K, W]
+}
+
+// EdmondsKarp finds maximum flow augmenting along shortest paths found by bfs
+// Runs in O(V*E^2)
+// https://en.wikipedia.org/wiki/Edmonds%E2%80%93Karp_algorithm
+func (g *Graph[K, V, W]) EdmondsKarp(source, sink K) (*MaxFlow[K, W], error) {
+	net, edges, s, t, err := g.flowNetwork(source, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	return g.maxFlow(net, edges, s, net.edmondsKarp(s, t)), nil
+}
+
+// Dinic finds maximum flow augmenting along blocking flows of level graph
+// Runs in O(V^2*E), much faster on unit capacity networks
+// https://en.wikipedia.org/wiki/Dinic%27s_algorithm
+func (g *Graph[K, V, W]) Dinic(source, sink K) (*MaxFlow[K, W], error) {
+	net, edges, s, t, err := g.flowNetwork(source, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	return g.maxFlow(net, edges, s, net.dinic(s, t)), nil
+}
+
+// MinCostMaxFlow finds maximum flow with minimum total cost,
+// where sending one unit of flow through edge from -> to costs cost(from, to)
+// Nil cost means every edge is free
+// Flow is augmented along cheapest paths found by bellman-ford
+// Returns *NegativeCycleError if edges with free capacity form a cycle of negative cost
+// https://en.wikipedia.org/wiki/Minimum-cost_flow_problem
+func (g *Graph[K, V, W]) MinCostMaxFlow(source, sink K, cost func(from, to K) W) (*MaxFlow[K, W], error) {
+	net, edges, s, t, err := g.flowNetwork(source, sink, cost)
+	if err != nil {
+		return nil, err
+	}
+	value, cycle := net.minCostFlow(s, t)
+	if cycle != nil {
+		return nil, &NegativeCycleError[K]{Cycle: g.keys(cycle)}
+	}
+	mf := g.maxFlow(net, edges, s, value)
+	if cost == nil {
+		return mf, nil
+	}
+	for _, e := range edges {
+		from, to := g.key(e.from), g.key(e.to)
+		mf.Cost += mf.Flow[[2]K{from, to}] * cost(from, to)
+	}
+	return mf, nil
+}
+
+// Matching is set of edges without common nodes
+type Matching[K comparable] struct {
+	// Pairs of matched nodes, left node first, sorted by insertion order of left node
+	Pairs [][2]K
+	// Mate maps every matched node to node matched with it,
+	// unmatched nodes are absent
+	Mate map[K]K
+}
+
+// Size returns number of matched pairs
+func (m *Matching[K]) Size() int {
+	return len(m.Pairs)
+}
+
+// HopcroftKarp finds maximum matching of bipartite graph
+// Left side consists of given nodes, right side of all other nodes,
+// graph is treated as undirected and every edge has to connect both sides
+// Matching is maximum flow of unit network source -> left -> right -> sink,
+// on such network each phase of Dinic is a phase of Hopcroft-Karp,
+// which gives O(E*sqrt(V)) running time
+// https://en.wikipedia.org/wiki/Hopcroft%E2%80%93Karp_algorithm
+func (g *Graph[K, V, W]) HopcroftKarp(left []K) (*Matching[K], error) {
+	isLeft := make([]bool, len(g.nodes))
+	for _, k := range left {
+		i, err := g.slot(k)
+		if err != nil {
+			return nil, err
+		}
+		isLeft[i] = true
+	}
+
+	var (
+		source, sink = len(g.nodes), len(g.nodes) + 1
+		net          = newFlowNetwork[int](len(g.nodes) + 2)
+		pairs        []edge[int] // left and right slot, weight is index of arc
+	)
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		if isLeft[i] {
+			net.addArc(source, i, 1, 0)
+		} else {
+			net.addArc(i, sink, 1, 0)
+		}
+		for _, j := range g.undirectedNeighbours(i) {
+			if j <= i {
+				continue
+			}
+			switch {
+			case isLeft[i] == isLeft[j]:
+				return nil, ErrNotBipartite
+			case isLeft[i]:
+				pairs = append(pairs, edge[int]{from: i, to: j, weight: net.addArc(i, j, 1, 0)})
+			default:
+				pairs = append(pairs, edge[int]{from: j, to: i, weight: net.addArc(j, i, 1, 0)})
+			}
+		}
+	}
+	net.dinic(source, sink)
+
+	var (
+		m    = &Matching[K]{Mate: make(map[K]K)}
+		mate = make([]int, len(g.nodes))
+	)
+	for n := range mate {
+		mate[n] = -1
+	}
+	for _, p := range pairs {
+		if net.flow(p.weight) == 1 {
+			mate[p.from], mate[p.to] = p.to, p.from
+		}
+	}
+	for n, j := range mate {
+		if j == -1 {
+			continue
+		}
+		m.Mate[g.key(n)] = g.key(j)
+		if isLeft[n] {
+			m.Pairs = append(m.Pairs, [2]K{g.key(n), g.key(j)})
+		}
+	}
+	return m, nil
+}
+
+// flowNetwork builds residual network from graph, arc 2*k corresponds
+// to k-th returned edge, undirected edge makes two opposite edges
+// Returns slots of source and sink
+func (g *Graph[K, V, W]) flowNetwork(source, sink K, cost func(from, to K) W) (*flowNetwork[W], []edge[W], int, int, error) {
+	s, err := g.slot(source)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	t, err := g.slot(sink)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	if s == t {
+		return nil, nil, 0, 0, ErrSourceIsSink
+	}
+	if g.hasNegativeWeights() {
+		return nil, nil, 0, 0, ErrNegativeWeight
+	}
+	var (
+		edges = g.arcList()
+		net   = newFlowNetwork[W](len(g.nodes))
+	)
+	for _, e := range edges {
+		var c W
+		if cost != nil {
+			c = cost(g.key(e.from), g.key(e.to))
+		}
+		net.addArc(e.from, e.to, e.weight, c)
+	}
+	return net, edges, s, t, nil
+}
+
+// maxFlow collects flow of every edge and minimum cut from residual network
+func (g *Graph[K, V, W]) maxFlow(net *flowNetwork[W], edges []edge[W], source int, value W) *MaxFlow[K, W] {
+	mf := &MaxFlow[K, W]{Value: value, Flow: make(map[[2]K]W, len(edges))}
+	for k, e := range edges {
+		mf.Flow[[2]K{g.key(e.from), g.key(e.to)}] = net.flow(2 * k)
+	}
+
+	reachable := net.reachable(source)
+	for n, ok := range reachable {
+		if ok && g.exists(n) {
+			mf.SourceSide = append(mf.SourceSide, g.key(n))
+		}
+	}
+	var cut []edge[W]
+	for _, e := range edges {
+		if reachable[e.from] && !reachable[e.to] {
+			cut = append(cut, e)
+		}
+	}
+	mf.Cut = g.publicEdges(cut)
+	return mf
+}
+
+// flowArc is arc of residual network
+type flowArc[W Number] struct {
+	to   int
+	cap  W // residual capacity
+	cost W
+}
+
+// flowNetwork is residual network stored as adjacency lists,
+// every arc is paired with reverse one, so arc a is reversed by a^1
+// Opposite edges between two nodes have their own arcs and flow
+type flowNetwork[W Number] struct {
+	adj  [][]int // indices of arcs leaving node
+	arcs []flowArc[W]
+}
+
+func newFlowNetwork[W Number](size int) *flowNetwork[W] {
+	return &flowNetwork[W]{adj: make([][]int, size)}
+}
+
+// addArc with given capacity and cost per unit of flow
+// Returns index of created arc
+func (net *flowNetwork[W]) addArc(from, to int, capacity, cost W) int {
+	a := len(net.arcs)
+	net.arcs = append(net.arcs,
+		flowArc[W]{to: to, cap: capacity, cost: cost},
+		flowArc[W]{to: from, cap: 0, cost: -cost},
+	)
+	net.adj[from] = append(net.adj[from], a)
+	net.adj[to] = append(net.adj[to], a^1)
+	return a
+}
+
+// flow through arc a equals residual capacity of its reverse
+func (net *flowNetwork[W]) flow(a int) W {
+	return net.arcs[a^1].cap
+}
+
+// push f units of flow along arc a
+func (net *flowNetwork[W]) push(a int, f W) {
+	net.arcs[a].cap -= f
+	net.arcs[a^1].cap += f
+}
+
+// augmentPath pushes maximum possible flow along path from source to sink,
+// where parent[n] is arc used to reach node n
+// Returns amount of flow pushed
+func (net *flowNetwork[W]) augmentPath(source, sink int, parent []int) W {
+	f := net.arcs[parent[sink]].cap
+	for n := sink; n != source; n = net.arcs[parent[n]^1].to {
+		f = min(f, net.arcs[parent[n]].cap)
+	}
+	for n := sink; n != source; n = net.arcs[parent[n]^1].to {
+		net.push(parent[n], f)
+	}
+	return f
+}
+
+// reachable returns nodes reachable from n through arcs with free capacity
+func (net *flowNetwork[W]) reachable(n int) []bool {
+	parent, _ := net.bfs(n, -1)
+	visited := make([]bool, len(parent))
+	for j, a := range parent {
+		visited[j] = j == n || a != -1
+	}
+	return visited
+}
+
+// bfs through arcs with free capacity until sink is reached
+// Returns arc used to reach every node, -1 for unvisited nodes, and level of every node
+func (net *flowNetwork[W]) bfs(source, sink int) ([]int, []int) {
+	var (
+		q      = queue.NewLLQueue(len(net.adj))
+		parent = make([]int, len(net.adj))
+		level  = make([]int, len(net.adj))
+	)
+	for n := range parent {
+		parent[n], level[n] = -1, -1
+	}
+	level[source] = 0
+
+	_ = q.Enqueue(source) // every node is enqueued at most once
+	for !q.Empty() {
+		n := q.Dequeue().(int)
+		if n == sink {
+			break
+		}
+		for _, a := range net.adj[n] {
+			arc := net.arcs[a]
+			if arc.cap > 0 && level[arc.to] == -1 {
+				parent[arc.to] = a
+				level[arc.to] = level[n] + 1
+				_ = q.Enqueue(arc.to)
+			}
+		}
+	}
+	return parent, level
+}
+
+func (net *flowNetwork[W]) edmondsKarp(source, sink int) W {
+	var total W
+	for {
+		parent, _ := net.bfs(source, sink)
+		if parent[sink] == -1 {
+			return total
+		}
+		total += net.augmentPath(source, sink, parent)
+	}
+}
+
+func (net *flowNetwork[W]) dinic(source, sink int) W {
+	var (
+		total W
+		limit W                           // no path carries more than capacity leaving source
+		next  = make([]int, len(net.adj)) // next arc to try, arcs before it are saturated
+	)
+	for _, a := range net.adj[source] {
+		limit += net.arcs[a].cap
+	}
+	for {
+		_, level := net.bfs(source, -1)
+		if level[sink] == -1 {
+			return total
+		}
+		for n := range next {
+			next[n] = 0
+		}
+		for {
+			f := net.blockingFlow(source, sink, limit, level, next)
+			if f == 0 {
+				break
+			}
+			total += f
+		}
+	}
+}
+
+// blockingFlow pushes up to limit units of flow from n to sink
+// along arcs going to next level
+func (net *flowNetwork[W]) blockingFlow(n, sink int, limit W, level, next []int) W {
+	if n == sink {
+		return limit
+	}
+	for ; next[n] < len(net.adj[n]); next[n]++ {
+		a := net.adj[n][next[n]]
+		arc := net.arcs[a]
+		if arc.cap == 0 || level[arc.to] != level[n]+1 {
+			continue
+		}
+		if f := net.blockingFlow(arc.to, sink, min(limit, arc.cap), level, next); f > 0 {
+			net.push(a, f)
+			return f
+		}
+	}
+	return 0
+}
+
+// minCostFlow augments flow along cheapest paths until sink is unreachable
+// Residual network never gets negative cycles if it had none initially,
+// so bellman-ford can be used on every iteration
+// Returns total flow, or nodes of negative cycle if network has one
+func (net *flowNetwork[W]) minCostFlow(source, sink int) (W, []int) {
+	var (
+		total   W
+		dist    = make([]W, len(net.adj))
+		reached = make([]bool, len(net.adj))
+		parent  = make([]int, len(net.adj))
+	)
+	for {
+		for n := range dist {
+			dist[n], reached[n], parent[n] = 0, false, -1
+		}
+		reached[source] = true
+
+		for round := 0; ; round++ {
+			updated := -1
+			for a, arc := range net.arcs {
+				from := net.arcs[a^1].to
+				if arc.cap == 0 || !reached[from] {
+					continue
+				}
+				if d := dist[from] + arc.cost; !reached[arc.to] || d < dist[arc.to] {
+					dist[arc.to], reached[arc.to] = d, true
+					parent[arc.to] = a
+					updated = arc.to
+				}
+			}
+			if updated == -1 {
+				break
+			}
+			if round == len(net.adj)-1 {
+				pred := make([]int, len(parent))
+				for n, a := range parent {
+					pred[n] = -1
+					if a != -1 {
+						pred[n] = net.arcs[a^1].to
+					}
+				}
+				return 0, predecessorsCycle(pred, updated)
+			}
+		}
+
+		if parent[sink] == -1 {
+			return total, nil
+		}
+		total += net.augmentPath(source, sink, parent)
+	}
+}
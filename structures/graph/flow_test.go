@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flowTestEdges is flow network from CLRS with source 0 and sink 5,
+// edge weights are capacities
+//
+//	0 -16-> 1 -12-> 3 -20-> 5
+//	0 -13-> 2 -14-> 4 --4-> 5
+//	2 --4-> 1,  3 --9-> 2,  4 --7-> 3
+var flowTestEdges = []struct{ i, j, w int }{
+	{0, 1, 16}, {0, 2, 13},
+	{1, 3, 12}, {2, 1, 4},
+	{2, 4, 14}, {3, 2, 9},
+	{3, 5, 20}, {4, 3, 7},
+	{4, 5, 4},
+}
+
+// assertFlow checks capacity and conservation constraints
+func assertFlow(t *testing.T, g *Graph[int, struct{}, int], mf *MaxFlow[int, int], source, sink int) {
+	balance := make(map[int]int)
+	for e, f := range mf.Flow {
+		w, ok := g.Weight(e[0], e[1])
+		assert.True(t, ok, "edge %v", e)
+		assert.GreaterOrEqual(t, f, 0)
+		assert.LessOrEqual(t, f, w)
+		balance[e[0]] -= f
+		balance[e[1]] += f
+	}
+	for _, n := range g.Nodes() {
+		b := balance[n]
+		switch n {
+		case source:
+			assert.Equal(t, -mf.Value, b)
+		case sink:
+			assert.Equal(t, mf.Value, b)
+		default:
+			assert.Zero(t, b, "node %d", n)
+		}
+	}
+
+	var cut int
+	for _, e := range mf.Cut {
+		assert.Equal(t, e.Weight, mf.Flow[[2]int{e.From, e.To}])
+		cut += e.Weight
+	}
+	assert.Equal(t, mf.Value, cut)
+}
+
+func TestGraph_MaxFlow(t *testing.T) {
+	for name, maxFlow := range map[string]func(*Graph[int, struct{}, int], int, int) (*MaxFlow[int, int], error){
+		"edmonds-karp": (*Graph[int, struct{}, int]).EdmondsKarp,
+		"dinic":        (*Graph[int, struct{}, int]).Dinic,
+		"min-cost": func(g *Graph[int, struct{}, int], source, sink int) (*MaxFlow[int, int], error) {
+			return g.MinCostMaxFlow(source, sink, func(int, int) int { return 1 })
+		},
+	} {
+		g := buildGraph(make([]struct{}, 6), flowTestEdges)
+
+		mf, err := maxFlow(g, 0, 5)
+		assert.NoError(t, err, name)
+		assert.Equal(t, 23, mf.Value, name)
+		assert.Equal(t, []int{0, 1, 2, 4}, mf.SourceSide, name)
+		assert.Equal(t, []Edge[int, int]{
+			{From: 1, To: 3, Weight: 12},
+			{From: 4, To: 3, Weight: 7},
+			{From: 4, To: 5, Weight: 4},
+		}, mf.Cut, name)
+		assertFlow(t, g, mf, 0, 5)
+
+		// edges in both directions between same nodes
+		g.CreateEdge(1, 2, 10)
+		g.CreateEdge(5, 3, 5)
+		mf, err = maxFlow(g, 0, 5)
+		assert.NoError(t, err, name)
+		assert.Equal(t, 23, mf.Value, name)
+		assertFlow(t, g, mf, 0, 5)
+
+		// sink is unreachable
+		mf, err = maxFlow(g, 5, 0)
+		assert.NoError(t, err, name)
+		assert.Equal(t, 0, mf.Value, name)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, mf.SourceSide, name)
+		assert.Empty(t, mf.Cut, name)
+
+		_, err = maxFlow(g, 0, 0)
+		assert.ErrorIs(t, err, ErrSourceIsSink, name)
+		_, err = maxFlow(g, 0, 6)
+		assert.ErrorIs(t, err, ErrNodeNotFound, name)
+		g.CreateEdge(0, 3, -1)
+		_, err = maxFlow(g, 0, 5)
+		assert.ErrorIs(t, err, ErrNegativeWeight, name)
+	}
+}
+
+func TestGraph_MaxFlow_Undirected(t *testing.T) {
+	// every edge carries flow in either direction
+	//
+	//	0 -3- 1 -2- 2
+	//	 \____1____/
+	g := NewGraph[string, struct{}, int](Undirected)
+	for _, k := range []string{"s", "a", "t"} {
+		g.InsertNode(k, struct{}{})
+	}
+	g.CreateEdge("s", "a", 3)
+	g.CreateEdge("a", "t", 2)
+	g.CreateEdge("t", "s", 1)
+
+	for name, maxFlow := range map[string]func(string, string) (*MaxFlow[string, int], error){
+		"edmonds-karp": g.EdmondsKarp,
+		"dinic":        g.Dinic,
+	} {
+		mf, err := maxFlow("s", "t")
+		assert.NoError(t, err, name)
+		assert.Equal(t, 3, mf.Value, name)
+		assert.Equal(t, 1, mf.Flow[[2]string{"s", "t"}], name)
+		assert.Equal(t, 0, mf.Flow[[2]string{"t", "s"}], name)
+		assert.Equal(t, []string{"s", "a"}, mf.SourceSide, name)
+
+		mf, err = maxFlow("t", "s")
+		assert.NoError(t, err, name)
+		assert.Equal(t, 3, mf.Value, name)
+		assert.Equal(t, 2, mf.Flow[[2]string{"a", "s"}], name)
+	}
+}
+
+func TestGraph_MinCostMaxFlow(t *testing.T) {
+	// edges are labeled capacity/cost, cheapest way to send
+	// 4 units is 2 units through 1 and 2 units directly through 2
+	//
+	//	0 -3/1-> 1 -2/1-> 3
+	//	0 -3/1-> 2 -2/4-> 3
+	//	1 -2/1-> 2
+	g := buildGraph(make([]struct{}, 4), []struct{ i, j, w int }{
+		{0, 1, 3}, {0, 2, 3}, {1, 3, 2}, {2, 3, 2}, {1, 2, 2},
+	})
+	costs := map[[2]int]int{{2, 3}: 4}
+	cost := func(i, j int) int {
+		if c, ok := costs[[2]int{i, j}]; ok {
+			return c
+		}
+		return 1
+	}
+
+	mf, err := g.MinCostMaxFlow(0, 3, cost)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, mf.Value)
+	assert.Equal(t, 14, mf.Cost)
+	assert.Equal(t, map[[2]int]int{
+		{0, 1}: 2, {0, 2}: 2,
+		{1, 2}: 0, {1, 3}: 2,
+		{2, 3}: 2,
+	}, mf.Flow)
+	assertFlow(t, g, mf, 0, 3)
+
+	// nil cost makes every edge free
+	mf, err = g.MinCostMaxFlow(0, 3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, mf.Value)
+	assert.Zero(t, mf.Cost)
+	assertFlow(t, g, mf, 0, 3)
+
+	// 1 -> 2 -> 1 has negative cost
+	g.CreateEdge(2, 1, 1)
+	costs[[2]int{1, 2}] = -2
+	_, err = g.MinCostMaxFlow(0, 3, cost)
+	var cycleErr *NegativeCycleError[int]
+	if assert.True(t, errors.As(err, &cycleErr)) {
+		assert.ElementsMatch(t, []int{1, 2}, cycleErr.Cycle)
+	}
+}
+
+func TestGraph_HopcroftKarp(t *testing.T) {
+	// left side is 0, 1, 2 and right side is 3, 4, 5, 6
+	// greedy matching of 0 with 3 would leave 1 unmatched
+	//
+	//	0 - 3, 0 - 4, 1 - 3, 2 - 4, 2 - 5
+	g := buildGraph(make([]struct{}, 7), []struct{ i, j, w int }{
+		{0, 3, 1}, {0, 4, 1}, {3, 1, 1}, {2, 4, 1}, {2, 5, 1},
+	})
+
+	m, err := g.HopcroftKarp([]int{0, 1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, [][2]int{{0, 4}, {1, 3}, {2, 5}}, m.Pairs)
+	assert.Equal(t, map[int]int{0: 4, 1: 3, 2: 5, 3: 1, 4: 0, 5: 2}, m.Mate)
+
+	g.RemoveNode(4)
+	m, err = g.HopcroftKarp([]int{0, 1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, m.Size())
+	assert.NotContains(t, m.Mate, 4)
+
+	_, err = g.HopcroftKarp([]int{0, 4})
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	g.CreateEdge(0, 1, 1)
+	_, err = g.HopcroftKarp([]int{0, 1, 2})
+	assert.ErrorIs(t, err, ErrNotBipartite)
+}
@@ -1,239 +1,402 @@
 //
-// Package graph implements graph data structure and basic operations with it.
+// Package graph implements generic graph data structure and basic operations with it.
+//
+// Graph is backed by adjacency lists, so memory it uses is proportional
+// to number of nodes and edges, nodes are identified by keys chosen by caller
+// and graph grows dynamically.
 //
 // https://en.wikipedia.org/wiki/Graph_theory
 // https://en.wikipedia.org/wiki/Graph_(abstract_data_type)
+// https://en.wikipedia.org/wiki/Adjacency_list
 // https://afteracademy.com/blog/introduction-to-graph-in-programming
 //
 package graph
 
 import (
-	"github.com/hasansino/gobasics/structures/queue"
-	"github.com/hasansino/gobasics/structures/stack"
+	"errors"
+	"slices"
+)
+
+var (
+	// ErrNodeExists is returned when inserting node with key already in graph
+	ErrNodeExists = errors.New("node already exists")
+	// ErrNodeNotFound is returned when referenced node is not in graph
+	ErrNodeNotFound = errors.New("node not found")
+	// ErrEdgeNotFound is returned when referenced edge is not in graph
+	ErrEdgeNotFound = errors.New("edge not found")
+)
+
+// Number is a constraint for edge weights
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Mode of a graph
+type Mode uint8
+
+const (
+	// Directed graph edges have direction
+	Directed Mode = iota
+	// Undirected graph edges connect both nodes in both directions
+	Undirected
 )
 
-// Graph is ... well, a graph
-type Graph struct {
-	size  int
-	nodes []*Node
-	edges [][]*Edge // Adjacency Matrix
+// Graph with nodes identified by keys K, holding values V
+// and connected by edges with weight W
+// Nodes are kept in insertion order, algorithms visit nodes and their
+// neighbours in that order, so their results are deterministic
+type Graph[K comparable, V any, W Number] struct {
+	mode    Mode
+	nodes   []*node[K, V, W] // slots in insertion order, nil for removed nodes
+	index   map[K]int        // slot of every node
+	edges   int
+	removed int // number of nil slots
 }
 
-// Node of a graph
-type Node struct {
-	value interface{}
+// node of a graph, adjacency lists hold slots of other nodes
+type node[K comparable, V any, W Number] struct {
+	key   K
+	value V
+	out   []arc[W] // sorted by slot of target
+	in    []int    // sorted slots of nodes having edge to this node
 }
 
-// Edge of graph
-type Edge struct {
-	weight int
+// arc is outgoing edge of a node
+type arc[W Number] struct {
+	to     int
+	weight W
 }
 
-// NewGraph creates new graph of given size
-func NewGraph(size int) *Graph {
-	edges := make([][]*Edge, size)
-	for i := 0; i < size; i++ {
-		edges[i] = make([]*Edge, size)
-	}
-	return &Graph{
-		size:  size,
-		nodes: make([]*Node, 0, size),
-		edges: edges,
+// Edge of graph with its weight
+type Edge[K comparable, W Number] struct {
+	From, To K
+	Weight   W
+}
+
+// NewGraph creates new empty graph
+func NewGraph[K comparable, V any, W Number](mode Mode) *Graph[K, V, W] {
+	return &Graph[K, V, W]{
+		mode:  mode,
+		index: make(map[K]int),
 	}
 }
 
-// Radius is minimum eccentricity of a graph
-func (g *Graph) Radius() int { return 0 }
+// exists tests if slot i holds a node
+func (g *Graph[K, V, W]) exists(i int) bool {
+	return g.nodes[i] != nil
+}
 
-// Diameter is maximum eccentricity of a graph
-func (g *Graph) Diameter() int { return 0 }
+// slot of node k, returns ErrNodeNotFound if node is not in graph
+func (g *Graph[K, V, W]) slot(k K) (int, error) {
+	i, ok := g.index[k]
+	if !ok {
+		return -1, ErrNodeNotFound
+	}
+	return i, nil
+}
 
-// CentralPoint is node whose eccentricity is equal to radius
-func (g *Graph) CentralPoint() int { return 0 }
+// key of node in slot i
+func (g *Graph[K, V, W]) key(i int) K {
+	return g.nodes[i].key
+}
 
-// Circumference returns number of edges in longest path of graph
-func (g *Graph) Circumference() int { return 0 }
+// keys of nodes in given slots
+func (g *Graph[K, V, W]) keys(slots []int) []K {
+	keys := make([]K, len(slots))
+	for j, i := range slots {
+		keys[j] = g.nodes[i].key
+	}
+	return keys
+}
 
-// Eccentricity returns maximum distance between given vertex n to any other
-func (g *Graph) Eccentricity() int { return 0 }
+// arcTo returns position of arc from slot i to slot j in adjacency list of i
+func (g *Graph[K, V, W]) arcTo(i, j int) (int, bool) {
+	return slices.BinarySearchFunc(g.nodes[i].out, j, func(a arc[W], j int) int {
+		return a.to - j
+	})
+}
 
-// InsertNode into graph with given value
-// Returns index of insertion
-func (g *Graph) InsertNode(v interface{}) int {
-	g.nodes = append(g.nodes, &Node{value: v})
-	return len(g.nodes)
+// Mode of the graph
+func (g *Graph[K, V, W]) Mode() Mode {
+	return g.mode
 }
 
-// RemoveNode from graph
-func (g *Graph) RemoveNode(i int) {
-	g.nodes[i] = nil
-	for j := 0; j < g.size; j++ {
-		g.edges[i][j] = nil
-	}
-	for j := 0; j < g.size; j++ {
-		g.edges[j][i] = nil
-	}
+// Len returns number of nodes in graph
+func (g *Graph[K, V, W]) Len() int {
+	return len(g.index)
 }
 
-// CreateEdge between i and j with weight w and direction d
-func (g *Graph) CreateEdge(i, j, w int) {
-	g.edges[i][j] = &Edge{weight: w}
+// EdgeCount returns number of edges
+// Undirected edge is counted once
+func (g *Graph[K, V, W]) EdgeCount() int {
+	return g.edges
 }
 
-// RemoveEdge between i and j
-func (g *Graph) RemoveEdge(i, j int) {
-	g.edges[i][j] = nil
+// Nodes returns keys of all nodes in insertion order
+func (g *Graph[K, V, W]) Nodes() []K {
+	keys := make([]K, 0, len(g.index))
+	for _, n := range g.nodes {
+		if n != nil {
+			keys = append(keys, n.key)
+		}
+	}
+	return keys
 }
 
-// Adjacent tests if i and j have an edge
-func (g *Graph) Adjacent(i, j int) bool {
-	return g.edges[i][j] != nil || g.edges[j][i] != nil
+// Value of node k
+func (g *Graph[K, V, W]) Value(k K) (V, error) {
+	i, err := g.slot(k)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return g.nodes[i].value, nil
 }
 
-// BreathFirstSearch of a graph
-// https://en.wikipedia.org/wiki/Breadth-first_search
-// https://afteracademy.com/blog/graph-traversal-breadth-first-search
-func (g *Graph) BreathFirstSearch(v interface{}) int {
-	var (
-		q       = queue.NewLLQueue(g.size)
-		visited = make(map[int]bool, g.size)
-	)
+// SetValue of node k
+func (g *Graph[K, V, W]) SetValue(k K, v V) error {
+	i, err := g.slot(k)
+	if err != nil {
+		return err
+	}
+	g.nodes[i].value = v
+	return nil
+}
 
-	if err := q.Enqueue(0); err != nil {
-		return -1
+// InsertNode with key k and value v
+// Returns ErrNodeExists if graph already has node with key k
+func (g *Graph[K, V, W]) InsertNode(k K, v V) error {
+	if _, ok := g.index[k]; ok {
+		return ErrNodeExists
 	}
+	g.index[k] = len(g.nodes)
+	g.nodes = append(g.nodes, &node[K, V, W]{key: k, value: v})
+	return nil
+}
 
-	for !q.Empty() {
-		n := q.Dequeue().(int)
-		if visited[n] {
-			continue
+// RemoveNode k from graph together with its edges
+func (g *Graph[K, V, W]) RemoveNode(k K) error {
+	i, err := g.slot(k)
+	if err != nil {
+		return err
+	}
+	n := g.nodes[i]
+	if g.mode == Undirected {
+		g.edges -= len(n.out)
+	} else {
+		g.edges -= len(n.out) + len(n.in)
+		if _, ok := g.arcTo(i, i); ok {
+			g.edges++ // self-loop is both incoming and outgoing
 		}
-		if g.nodes[n].value == v {
-			return n
+	}
+	for _, a := range n.out {
+		if a.to != i {
+			g.nodes[a.to].in = removeSlot(g.nodes[a.to].in, i)
 		}
-		visited[n] = true
-		for j := 0; j < g.size; j++ {
-			if g.edges[n][j] != nil {
-				if err := q.Enqueue(j); err != nil {
-					return -1
-				}
-			}
+	}
+	for _, j := range n.in {
+		if j != i {
+			p, _ := g.arcTo(j, i)
+			g.nodes[j].out = slices.Delete(g.nodes[j].out, p, p+1)
 		}
 	}
-
-	return -1
+	g.nodes[i] = nil
+	delete(g.index, k)
+	if g.removed++; g.removed > len(g.nodes)/2 {
+		g.compact()
+	}
+	return nil
 }
 
-// DepthFirstSearch of graph
-// https://en.wikipedia.org/wiki/Depth-first_search
-// https://afteracademy.com/blog/graph-traversal-depth-first-search
-func (g *Graph) DepthFirstSearch(v interface{}) int {
+// compact drops slots of removed nodes
+// Remaining nodes keep their order, so adjacency lists stay sorted
+func (g *Graph[K, V, W]) compact() {
 	var (
-		s       = stack.NewLLStack()
-		visited = make(map[int]bool, g.size)
+		remap = make([]int, len(g.nodes))
+		nodes = make([]*node[K, V, W], 0, len(g.index))
 	)
-
-	s.Push(0)
-
-	for !s.Empty() {
-		n := s.Pop().(int)
-		if visited[n] {
-			continue
+	for i, n := range g.nodes {
+		if n != nil {
+			remap[i] = len(nodes)
+			nodes = append(nodes, n)
 		}
-		if g.nodes[n].value == v {
-			return n
+	}
+	for i, n := range nodes {
+		g.index[n.key] = i
+		for j := range n.out {
+			n.out[j].to = remap[n.out[j].to]
 		}
-		visited[n] = true
-		for j := 0; j < g.size; j++ {
-			if g.edges[n][j] != nil {
-				s.Push(j)
-			}
+		for j := range n.in {
+			n.in[j] = remap[n.in[j]]
 		}
 	}
+	g.nodes, g.removed = nodes, 0
+}
 
-	return -1
+// CreateEdge from one node to another with weight w
+// Weight is updated if edge already exists
+// In undirected graph edge is created in both directions
+func (g *Graph[K, V, W]) CreateEdge(from, to K, w W) error {
+	i, err := g.slot(from)
+	if err != nil {
+		return err
+	}
+	j, err := g.slot(to)
+	if err != nil {
+		return err
+	}
+	created := g.link(i, j, w)
+	if g.mode == Undirected && i != j {
+		g.link(j, i, w)
+	}
+	if created {
+		g.edges++
+	}
+	return nil
 }
 
-// ShortestPathQueueItem with path already traversed for backtracking
-type ShortestPathQueueItem struct {
-	path []int
-	node int
+// RemoveEdge from one node to another
+// In undirected graph edge is removed in both directions
+func (g *Graph[K, V, W]) RemoveEdge(from, to K) error {
+	i, err := g.slot(from)
+	if err != nil {
+		return err
+	}
+	j, err := g.slot(to)
+	if err != nil {
+		return err
+	}
+	if !g.unlink(i, j) {
+		return ErrEdgeNotFound
+	}
+	if g.mode == Undirected && i != j {
+		g.unlink(j, i)
+	}
+	g.edges--
+	return nil
 }
 
-// ShortestPath from root node to node with given value
-func (g *Graph) ShortestPath(v interface{}) []int {
-	var (
-		q       = queue.NewLLQueue(g.size)
-		visited = make(map[int]bool, g.size)
-	)
+// link creates or updates arc from slot i to slot j, returns true if arc was created
+func (g *Graph[K, V, W]) link(i, j int, w W) bool {
+	p, ok := g.arcTo(i, j)
+	if ok {
+		g.nodes[i].out[p].weight = w
+		return false
+	}
+	g.nodes[i].out = slices.Insert(g.nodes[i].out, p, arc[W]{to: j, weight: w})
+	q, _ := slices.BinarySearch(g.nodes[j].in, i)
+	g.nodes[j].in = slices.Insert(g.nodes[j].in, q, i)
+	return true
+}
 
-	if err := q.Enqueue(ShortestPathQueueItem{
-		node: 0, path: make([]int, 0),
-	}); err != nil {
-		return nil
+// unlink removes arc from slot i to slot j, returns false if there is none
+func (g *Graph[K, V, W]) unlink(i, j int) bool {
+	p, ok := g.arcTo(i, j)
+	if !ok {
+		return false
 	}
+	g.nodes[i].out = slices.Delete(g.nodes[i].out, p, p+1)
+	g.nodes[j].in = removeSlot(g.nodes[j].in, i)
+	return true
+}
 
-	for !q.Empty() {
-		qi := q.Dequeue().(ShortestPathQueueItem)
-		if visited[qi.node] {
-			continue
-		}
-		if g.nodes[qi.node].value == v {
-			qi.path = append(qi.path, qi.node)
-			return qi.path
-		}
-		visited[qi.node] = true
-		for j := 0; j < g.size; j++ {
-			if g.edges[qi.node][j] != nil {
-				if err := q.Enqueue(ShortestPathQueueItem{
-					node: j,
-					path: append(qi.path, qi.node),
-				}); err != nil {
-					return nil
-				}
-			}
-		}
+// removeSlot from sorted list of slots
+func removeSlot(slots []int, i int) []int {
+	if p, ok := slices.BinarySearch(slots, i); ok {
+		return slices.Delete(slots, p, p+1)
 	}
+	return slots
+}
 
-	return nil
+// Adjacent tests if there is an edge from one node to another
+// Returns false if any of nodes is not in graph
+func (g *Graph[K, V, W]) Adjacent(from, to K) bool {
+	_, ok := g.Weight(from, to)
+	return ok
 }
 
-// DijkstrasShortestDistances algorithm
-// https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm
-// https://afteracademy.com/blog/dijkstras-algorithm
-func (g *Graph) DijkstrasShortestDistances(from int) []int {
-	var (
-		visited   = make([]bool, g.size)
-		distances = make([]int, g.size)
-	)
+// Weight of edge from one node to another
+// Returns false if there is no such edge
+func (g *Graph[K, V, W]) Weight(from, to K) (W, bool) {
+	i, ok := g.index[from]
+	if !ok {
+		return 0, false
+	}
+	j, ok := g.index[to]
+	if !ok {
+		return 0, false
+	}
+	p, ok := g.arcTo(i, j)
+	if !ok {
+		return 0, false
+	}
+	return g.nodes[i].out[p].weight, true
+}
+
+// Edges returns outgoing edges of node k in insertion order of their targets
+func (g *Graph[K, V, W]) Edges(k K) []Edge[K, W] {
+	i, ok := g.index[k]
+	if !ok {
+		return nil
+	}
+	edges := make([]Edge[K, W], len(g.nodes[i].out))
+	for j, a := range g.nodes[i].out {
+		edges[j] = Edge[K, W]{From: k, To: g.key(a.to), Weight: a.weight}
+	}
+	return edges
+}
 
-	for j := 0; j < g.size; j++ {
-		distances[j] = -1 // undefined
+// BreathFirstSearch from node with key from for node satisfying match
+// Returns key of first matching node, false if there is none
+// https://afteracademy.com/blog/graph-traversal-breadth-first-search
+func (g *Graph[K, V, W]) BreathFirstSearch(from K, match func(k K, v V) bool) (K, bool) {
+	if i, ok := g.index[from]; ok {
+		for n := range g.bfs(i) {
+			if match(g.nodes[n].key, g.nodes[n].value) {
+				return g.nodes[n].key, true
+			}
+		}
 	}
-	distances[from] = 0
+	var zero K
+	return zero, false
+}
 
-	for j := 0; j < g.size; j++ {
-		next := g.dijkstrasShortestPathNextNode(visited, distances)
-		visited[next] = true
-		for k := 0; k < g.size; k++ {
-			if !visited[k] && g.edges[next][k] != nil {
-				if distances[k] < 0 || distances[next]+g.edges[next][k].weight < distances[k] {
-					distances[k] = distances[next] + g.edges[next][k].weight
-				}
+// DepthFirstSearch from node with key from for node satisfying match
+// Returns key of first matching node, false if there is none
+// https://afteracademy.com/blog/graph-traversal-depth-first-search
+func (g *Graph[K, V, W]) DepthFirstSearch(from K, match func(k K, v V) bool) (K, bool) {
+	if i, ok := g.index[from]; ok {
+		for n, visit := range g.dfs(i) {
+			if visit == PreVisit && match(g.nodes[n].key, g.nodes[n].value) {
+				return g.nodes[n].key, true
 			}
 		}
 	}
+	var zero K
+	return zero, false
+}
 
-	return distances
+// ShortestPath from one node to another minimizing sum of edge weights
+// Returns nil path if target is unreachable
+// and ErrNegativeWeight if graph has negative edges
+func (g *Graph[K, V, W]) ShortestPath(from, to K) ([]K, W, error) {
+	sp, err := g.DijkstraTo(from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sp.PathTo(to), sp.Distances[to], nil
 }
 
-func (g *Graph) dijkstrasShortestPathNextNode(visited []bool, distances []int) int {
-	var minIdx = -1
-	for j := 0; j < g.size; j++ {
-		if !visited[j] && distances[j] >= 0 &&
-			(minIdx == -1 || distances[j] < distances[minIdx]) {
-			minIdx = j
-		}
+// DijkstrasShortestDistances from node to every reachable node
+// Unreachable nodes are absent from result
+// https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm
+// https://afteracademy.com/blog/dijkstras-algorithm
+func (g *Graph[K, V, W]) DijkstrasShortestDistances(from K) (map[K]W, error) {
+	sp, err := g.Dijkstra(from)
+	if err != nil {
+		return nil, err
 	}
-	return minIdx
+	return sp.Distances, nil
 }
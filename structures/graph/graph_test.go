@@ -14,8 +14,8 @@ var (
 	//   33   654
 	//    \  /
 	//      2
-	//                        0  1  2   3    4  5    6
-	testNodes = []interface{}{4, 6, 33, 654, 2, 234, 546}
+	//                  0  1  2   3    4  5    6
+	testNodes = []int{4, 6, 33, 654, 2, 234, 546}
 	testEdges = []struct {
 		i, j, w int
 	}{
@@ -38,115 +38,335 @@ var (
 	}
 )
 
-func TestGraph_Build(t *testing.T) {
-	g := NewGraph(len(testNodes))
-	for _, v := range testNodes {
-		g.InsertNode(v)
+// testKeyedNodes and testKeyedEdges form the same graph as testNodes
+// and testEdges, keyed by strings and with every edge listed once
+//
+//	      b
+//	     / \
+//	a - c   g
+//	 \   \ /
+//	  d   e
+//	   \ /
+//	    f
+var (
+	testKeyedNodes = map[string]int{"a": 4, "c": 6, "d": 33, "e": 654, "f": 2, "b": 234, "g": 546}
+	testKeyedOrder = []string{"a", "c", "d", "e", "f", "b", "g"}
+	testKeyedEdges = []struct {
+		from, to string
+		w        float64
+	}{
+		{"a", "c", 2},
+		{"c", "b", 2},
+		{"b", "g", 2},
+		{"g", "e", 2},
+		{"e", "f", 2},
+		{"a", "d", 9},
+		{"c", "e", 9},
+		{"d", "f", 9},
 	}
-	for _, v := range testEdges {
-		g.CreateEdge(v.i, v.j, v.w)
+)
+
+func newKeyedTestGraph(t *testing.T, mode Mode) *Graph[string, int, float64] {
+	g := NewGraph[string, int, float64](mode)
+	for _, k := range testKeyedOrder {
+		assert.NoError(t, g.InsertNode(k, testKeyedNodes[k]))
+	}
+	for _, e := range testKeyedEdges {
+		assert.NoError(t, g.CreateEdge(e.from, e.to, e.w))
 	}
+	return g
+}
+
+func TestGraph_Build(t *testing.T) {
+	g := newKeyedTestGraph(t, Undirected)
+	assert.Equal(t, Undirected, g.Mode())
+	assert.Equal(t, 7, g.Len())
+	assert.Equal(t, 8, g.EdgeCount())
+	assert.Equal(t, testKeyedOrder, g.Nodes())
+
+	assert.ErrorIs(t, g.InsertNode("a", 1), ErrNodeExists)
+	assert.ErrorIs(t, g.CreateEdge("a", "z", 1), ErrNodeNotFound)
+	assert.ErrorIs(t, g.CreateEdge("z", "a", 1), ErrNodeNotFound)
+
+	v, err := g.Value("e")
+	assert.NoError(t, err)
+	assert.Equal(t, 654, v)
+	_, err = g.Value("z")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	assert.NoError(t, g.SetValue("e", 655))
+	v, _ = g.Value("e")
+	assert.Equal(t, 655, v)
+	assert.ErrorIs(t, g.SetValue("z", 1), ErrNodeNotFound)
+
+	assert.True(t, g.Adjacent("a", "c"))
+	assert.True(t, g.Adjacent("c", "a"))
+	assert.False(t, g.Adjacent("a", "b"))
+	assert.False(t, g.Adjacent("a", "z"))
+	// edges are listed in insertion order of their targets
+	assert.Equal(t, []Edge[string, float64]{
+		{From: "c", To: "a", Weight: 2},
+		{From: "c", To: "e", Weight: 9},
+		{From: "c", To: "b", Weight: 2},
+	}, g.Edges("c"))
+	assert.Nil(t, g.Edges("z"))
+
+	// updating weight does not create new edge
+	assert.NoError(t, g.CreateEdge("c", "a", 3))
+	w, ok := g.Weight("a", "c")
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), w)
+	assert.Equal(t, 8, g.EdgeCount())
+	_, ok = g.Weight("a", "b")
+	assert.False(t, ok)
+}
+
+func TestGraph_Directed(t *testing.T) {
+	g := newKeyedTestGraph(t, Directed)
+	assert.Equal(t, Directed, g.Mode())
+	assert.Equal(t, 8, g.EdgeCount())
+	assert.True(t, g.Adjacent("a", "c"))
+	assert.False(t, g.Adjacent("c", "a"))
+
+	assert.NoError(t, g.CreateEdge("e", "e", 1))
+	assert.NoError(t, g.CreateEdge("f", "e", 1))
+	assert.Equal(t, 10, g.EdgeCount())
+
+	// g->e, c->e, e->f, e->e, f->e
+	assert.NoError(t, g.RemoveNode("e"))
+	assert.Equal(t, 5, g.EdgeCount())
+	assert.Equal(t, 6, g.Len())
+	assert.Empty(t, g.Edges("g"))
+	assert.Equal(t, []Edge[string, float64]{{From: "c", To: "b", Weight: 2}}, g.Edges("c"))
+	assert.ErrorIs(t, g.RemoveNode("e"), ErrNodeNotFound)
+}
+
+func TestGraph_Remove(t *testing.T) {
+	g := newKeyedTestGraph(t, Undirected)
+
+	assert.NoError(t, g.RemoveEdge("b", "c"))
+	assert.False(t, g.Adjacent("b", "c"))
+	assert.False(t, g.Adjacent("c", "b"))
+	assert.ErrorIs(t, g.RemoveEdge("b", "c"), ErrEdgeNotFound)
+	assert.ErrorIs(t, g.RemoveEdge("b", "z"), ErrNodeNotFound)
+	assert.Equal(t, 7, g.EdgeCount())
+
+	assert.NoError(t, g.RemoveNode("c"))
+	assert.Equal(t, 6, g.Len())
+	assert.Equal(t, 5, g.EdgeCount())
+	assert.Equal(t, []string{"a", "d", "e", "f", "b", "g"}, g.Nodes())
+	assert.Equal(t, []Edge[string, float64]{{From: "a", To: "d", Weight: 9}}, g.Edges("a"))
+	assert.Equal(t, []Edge[string, float64]{
+		{From: "e", To: "f", Weight: 2},
+		{From: "e", To: "g", Weight: 2},
+	}, g.Edges("e"))
+
+	// self-loop is single edge
+	assert.NoError(t, g.CreateEdge("a", "a", 1))
+	assert.Equal(t, 6, g.EdgeCount())
+	assert.NoError(t, g.RemoveNode("a"))
+	assert.Equal(t, 4, g.EdgeCount())
 }
 
 func TestGraph_BFS(t *testing.T) {
-	g := NewGraph(len(testNodes))
-	for _, v := range testNodes {
-		g.InsertNode(v)
-	}
-	for _, v := range testEdges {
-		g.CreateEdge(v.i, v.j, v.w)
+	g := buildGraph(testNodes, testEdges)
+	for n, v := range testNodes {
+		found, ok := g.BreathFirstSearch(0, func(_ int, value int) bool { return value == v })
+		assert.True(t, ok)
+		assert.Equal(t, n, found)
 	}
+	_, ok := g.BreathFirstSearch(0, func(_ int, value int) bool { return value == 999 })
+	assert.False(t, ok)
+
+	kg := newKeyedTestGraph(t, Undirected)
+	var order []string
+	_, ok = kg.BreathFirstSearch("a", func(k string, _ int) bool {
+		order = append(order, k)
+		return false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a", "c", "d", "e", "b", "f", "g"}, order)
 
-	assert.Equal(t, 0, g.BreathFirstSearch(4))
-	assert.Equal(t, 1, g.BreathFirstSearch(6))
-	assert.Equal(t, 2, g.BreathFirstSearch(33))
-	assert.Equal(t, 3, g.BreathFirstSearch(654))
-	assert.Equal(t, 4, g.BreathFirstSearch(2))
-	assert.Equal(t, 5, g.BreathFirstSearch(234))
-	assert.Equal(t, 6, g.BreathFirstSearch(546))
-
-	assert.Equal(t, -1, g.BreathFirstSearch(0))
-	assert.Equal(t, -1, g.BreathFirstSearch(1))
-	assert.Equal(t, -1, g.BreathFirstSearch(999))
+	_, ok = kg.BreathFirstSearch("z", func(string, int) bool { return true })
+	assert.False(t, ok)
 }
 
 func TestGraph_DFS(t *testing.T) {
-	g := NewGraph(len(testNodes))
-	for _, v := range testNodes {
-		g.InsertNode(v)
-	}
-	for _, v := range testEdges {
-		g.CreateEdge(v.i, v.j, v.w)
+	g := buildGraph(testNodes, testEdges)
+	for n, v := range testNodes {
+		found, ok := g.DepthFirstSearch(0, func(_ int, value int) bool { return value == v })
+		assert.True(t, ok)
+		assert.Equal(t, n, found)
 	}
+	_, ok := g.DepthFirstSearch(0, func(_ int, value int) bool { return value == 999 })
+	assert.False(t, ok)
+
+	kg := newKeyedTestGraph(t, Undirected)
+	var order []string
+	_, ok = kg.DepthFirstSearch("a", func(k string, _ int) bool {
+		order = append(order, k)
+		return false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a", "c", "e", "f", "d", "g", "b"}, order)
 
-	assert.Equal(t, 0, g.DepthFirstSearch(4))
-	assert.Equal(t, 1, g.DepthFirstSearch(6))
-	assert.Equal(t, 2, g.DepthFirstSearch(33))
-	assert.Equal(t, 3, g.DepthFirstSearch(654))
-	assert.Equal(t, 4, g.DepthFirstSearch(2))
-	assert.Equal(t, 5, g.DepthFirstSearch(234))
-	assert.Equal(t, 6, g.DepthFirstSearch(546))
-
-	assert.Equal(t, -1, g.DepthFirstSearch(0))
-	assert.Equal(t, -1, g.DepthFirstSearch(1))
-	assert.Equal(t, -1, g.DepthFirstSearch(999))
+	_, ok = kg.DepthFirstSearch("z", func(string, int) bool { return true })
+	assert.False(t, ok)
 }
 
 func TestGraph_ShortestPath(t *testing.T) {
-	g := NewGraph(len(testNodes))
-	for _, v := range testNodes {
-		g.InsertNode(v)
-	}
-	for _, v := range testEdges {
-		g.CreateEdge(v.i, v.j, v.w)
-	}
-	assert.Equal(t, []int{0}, g.ShortestPath(4))
-	assert.Equal(t, []int{0, 2, 4}, g.ShortestPath(2))
-	assert.Equal(t, []int{0, 1, 3, 6}, g.ShortestPath(546))
+	g := buildGraph(testNodes, testEdges)
+
+	path, dist, err := g.ShortestPath(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, path)
+	assert.Equal(t, 0, dist)
+	path, dist, _ = g.ShortestPath(0, 4)
+	assert.Equal(t, []int{0, 2, 4}, path)
+	assert.Equal(t, 2, dist)
+	path, dist, _ = g.ShortestPath(0, 6)
+	assert.Equal(t, []int{0, 1, 3, 6}, path)
+	assert.Equal(t, 3, dist)
+
+	kg := newKeyedTestGraph(t, Directed)
+	kpath, kdist, err := kg.ShortestPath("a", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b", "g", "e", "f"}, kpath)
+	assert.Equal(t, float64(10), kdist)
+
+	kpath, _, err = kg.ShortestPath("f", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, kpath)
+
+	_, _, err = kg.ShortestPath("a", "z")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
 }
 
 func TestGraph_DijkstrasShortestDistances(t *testing.T) {
-	var (
-		//        234
-		//       /   \
-		//	4 - 6    546
-		//   \   \   /
-		//   33   654
-		//    \  /
-		//      2
-		//                        0  1  2   3    4  5    6
-		testNodes = []interface{}{4, 6, 33, 654, 2, 234, 546}
-		testEdges = []struct {
-			i, j, w int
-		}{
-			{0, 1, 2},
-			{1, 0, 2},
-			{1, 5, 2},
-			{5, 1, 2},
-			{5, 6, 2},
-			{6, 5, 2},
-			{6, 3, 2},
-			{3, 6, 2},
-			{3, 4, 2},
-			{4, 3, 2},
-
-			{0, 2, 9},
-			{2, 0, 9},
-			{1, 3, 9},
-			{3, 1, 9},
-			{2, 4, 9},
-			{4, 2, 9},
+	g := buildGraph(testNodes, weightedTestEdges)
+
+	dist, err := g.DijkstrasShortestDistances(0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 0, 1: 2, 2: 9, 3: 8, 4: 10, 5: 4, 6: 6}, dist)
+	dist, err = g.DijkstrasShortestDistances(3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 8, 1: 6, 2: 11, 3: 0, 4: 2, 5: 4, 6: 2}, dist)
+
+	kg := newKeyedTestGraph(t, Undirected)
+	kdist, err := kg.DijkstrasShortestDistances("e")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"a": 8, "c": 6, "d": 11, "e": 0, "f": 2, "b": 4, "g": 2,
+	}, kdist)
+
+	// unreachable nodes are absent
+	assert.NoError(t, kg.InsertNode("z", 0))
+	kdist, err = kg.DijkstrasShortestDistances("a")
+	assert.NoError(t, err)
+	assert.NotContains(t, kdist, "z")
+
+	_, err = kg.DijkstrasShortestDistances("y")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	assert.NoError(t, kg.CreateEdge("z", "a", -1))
+	_, err = kg.DijkstrasShortestDistances("a")
+	assert.ErrorIs(t, err, ErrNegativeWeight)
+}
+
+func TestGraph_SparseKeys(t *testing.T) {
+	// memory is proportional to number of nodes, not to values of keys
+	g := NewGraph[int, struct{}, uint8](Directed)
+	for k := 0; k < 1_000_000; k += 100_000 {
+		assert.NoError(t, g.InsertNode(k, struct{}{}))
+		if k > 0 {
+			assert.NoError(t, g.CreateEdge(k-100_000, k, 1))
 		}
-	)
+	}
+	dist, err := g.DijkstrasShortestDistances(0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(9), dist[900_000])
+}
 
-	g := NewGraph(len(testNodes))
-	for _, v := range testNodes {
-		g.InsertNode(v)
+func TestGraph_RemoveNode(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+
+	assert.NoError(t, g.RemoveNode(0))
+	assert.Equal(t, 6, g.Len())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, g.Nodes())
+	assert.False(t, g.Adjacent(0, 1))
+	assert.False(t, g.Adjacent(1, 0))
+
+	// key of removed node can be inserted again, it goes last
+	assert.NoError(t, g.InsertNode(0, 999))
+	assert.NoError(t, g.CreateEdge(1, 0, 1))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 0}, g.Nodes())
+
+	found, ok := g.BreathFirstSearch(1, func(_ int, v int) bool { return v == 999 })
+	assert.True(t, ok)
+	assert.Equal(t, 0, found)
+	_, ok = g.DepthFirstSearch(2, func(_ int, v int) bool { return v == 4 })
+	assert.False(t, ok)
+	path, _, err := g.ShortestPath(3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 0}, path)
+
+	for _, n := range g.Nodes() {
+		assert.NoError(t, g.RemoveNode(n))
 	}
-	for _, v := range testEdges {
-		g.CreateEdge(v.i, v.j, v.w)
+	assert.Equal(t, 0, g.Len())
+	assert.Equal(t, 0, g.EdgeCount())
+	assert.Empty(t, g.Nodes())
+}
+
+// removing most of nodes compacts slots, results are not affected
+func TestGraph_Compact(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+	for _, n := range []int{0, 2, 4} {
+		assert.NoError(t, g.RemoveNode(n))
 	}
+	assert.Len(t, g.nodes, 7)
+	assert.NoError(t, g.RemoveNode(6))
+	assert.Len(t, g.nodes, 3)
+	assert.Equal(t, []int{1, 3, 5}, g.Nodes())
+	assert.Equal(t, 4, g.EdgeCount())
+	assert.Equal(t, []Edge[int, int]{
+		{From: 1, To: 3, Weight: 9},
+		{From: 1, To: 5, Weight: 2},
+	}, g.Edges(1))
+
+	sp, err := g.Dijkstra(3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{1: 9, 3: 0, 5: 11}, sp.Distances)
+	assert.Equal(t, []int{3, 1, 5}, sp.PathTo(5))
+	sorted, err := g.TopologicalSort()
+	assert.Nil(t, sorted)
+	assert.Error(t, err)
+	assert.Len(t, g.StronglyConnectedComponents().Membership, 3)
+}
+
+func TestGraph_Errors(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+	assert.NoError(t, g.RemoveNode(2))
+
+	assert.ErrorIs(t, g.RemoveNode(2), ErrNodeNotFound)
+	assert.ErrorIs(t, g.RemoveNode(7), ErrNodeNotFound)
+	assert.ErrorIs(t, g.InsertNode(0, 1), ErrNodeExists)
+
+	assert.ErrorIs(t, g.CreateEdge(0, 2, 1), ErrNodeNotFound)
+	assert.ErrorIs(t, g.CreateEdge(7, 0, 1), ErrNodeNotFound)
+	assert.ErrorIs(t, g.RemoveEdge(0, 7), ErrNodeNotFound)
+	assert.ErrorIs(t, g.RemoveEdge(0, 4), ErrEdgeNotFound)
+	assert.NoError(t, g.RemoveEdge(0, 1))
+	assert.ErrorIs(t, g.RemoveEdge(0, 1), ErrEdgeNotFound)
+
+	assert.False(t, g.Adjacent(0, 7))
+	assert.False(t, g.Adjacent(-1, 0))
+
+	_, err := g.Value(2)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	assert.ErrorIs(t, g.SetValue(100, 1), ErrNodeNotFound)
 
-	assert.Equal(t, []int{0, 2, 9, 8, 10, 4, 6}, g.DijkstrasShortestDistances(0))
-	assert.Equal(t, []int{8, 6, 11, 0, 2, 4, 2}, g.DijkstrasShortestDistances(3))
+	_, err = g.Dijkstra(100)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	_, err = g.Eccentricity(2)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
 }
@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// GraphML is XML based graph format supported by most graph tools
+// Node values are stored as "label" attribute and edge weights as "weight" attribute
+//
+//	<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+//	  <key id="label" for="node" attr.name="label" attr.type="string"></key>
+//	  <key id="weight" for="edge" attr.name="weight" attr.type="int"></key>
+//	  <graph edgedefault="directed">
+//	    <node id="0"><data key="label">4</data></node>
+//	    <edge source="0" target="1"><data key="weight">2</data></edge>
+//	  </graph>
+//	</graphml>
+//
+// Undirected graph is written with undirected edgedefault
+// Reader finds attributes by their names, so keys can have any ids
+// Edges without weight have weight 1
+// http://graphml.graphdrawing.org/primer/graphml-primer.html
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed string        `xml:"directed,attr,omitempty"`
+	Data     []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes graph in GraphML format
+func (g *Graph[K, V, W]) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		Xmlns: graphMLNamespace,
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "weight", For: "edge", Name: "weight", Type: graphMLType[W]()},
+		},
+		Graph: graphMLGraph{EdgeDefault: "directed"},
+	}
+	if g.mode == Undirected {
+		doc.Graph.EdgeDefault = "undirected"
+	}
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		node := graphMLNode{ID: fmt.Sprint(g.key(i))}
+		if label, ok := g.nodeLabel(i); ok {
+			node.Data = []graphMLData{{Key: "label", Value: label}}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range g.edgeList() {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: fmt.Sprint(g.key(e.from)),
+			Target: fmt.Sprint(g.key(e.to)),
+			Data:   []graphMLData{{Key: "weight", Value: fmt.Sprint(e.weight)}},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// graphMLType returns GraphML type of weights
+func graphMLType[W Number]() string {
+	switch reflect.TypeFor[W]().Kind() {
+	case reflect.Float32, reflect.Float64:
+		return "double"
+	default:
+		return "int"
+	}
+}
+
+// ReadGraphML reads graph in GraphML format
+func ReadGraphML[K comparable, V any, W Number](r io.Reader, decoder Decoder[K, V]) (*Graph[K, V, W], error) {
+	var doc graphML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	// keys are referenced by ids, default to ids equal to names
+	labelKey, weightKey := "label", "weight"
+	for _, k := range doc.Keys {
+		switch {
+		case k.Name == "label" && (k.For == "node" || k.For == "all"):
+			labelKey = k.ID
+		case k.Name == "weight" && (k.For == "edge" || k.For == "all"):
+			weightKey = k.ID
+		}
+	}
+
+	b := newGraphBuilder[K, V, W](decoder)
+	if doc.Graph.EdgeDefault == "undirected" {
+		b.mode = Undirected
+	}
+	for _, node := range doc.Graph.Nodes {
+		k, err := b.node(node.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range node.Data {
+			if d.Key != labelKey {
+				continue
+			}
+			if err := b.label(k, d.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, edge := range doc.Graph.Edges {
+		from, err := b.node(edge.Source)
+		if err != nil {
+			return nil, err
+		}
+		to, err := b.node(edge.Target)
+		if err != nil {
+			return nil, err
+		}
+		weight := "1"
+		for _, d := range edge.Data {
+			if d.Key == weightKey {
+				weight = strings.TrimSpace(d.Value)
+			}
+		}
+		undirected := b.mode == Undirected
+		if edge.Directed != "" {
+			undirected = edge.Directed != "true"
+		}
+		if err := b.edge(from, to, weight, undirected); err != nil {
+			return nil, err
+		}
+	}
+	return b.build(), nil
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_WriteGraphML(t *testing.T) {
+	g := buildGraph([]interface{}{4, nil}, []struct{ i, j, w int }{{0, 1, 2}})
+
+	var buf bytes.Buffer
+	assert.NoError(t, g.WriteGraphML(&buf))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="label" for="node" attr.name="label" attr.type="string"></key>
+  <key id="weight" for="edge" attr.name="weight" attr.type="int"></key>
+  <graph edgedefault="directed">
+    <node id="0">
+      <data key="label">4</data>
+    </node>
+    <node id="1"></node>
+    <edge source="0" target="1">
+      <data key="weight">2</data>
+    </edge>
+  </graph>
+</graphml>
+`, buf.String())
+
+	// floating point weights of undirected graph
+	buf.Reset()
+	assert.NoError(t, newKeyedTestGraph(t, Undirected).WriteGraphML(&buf))
+	assert.Contains(t, buf.String(), `<key id="weight" for="edge" attr.name="weight" attr.type="double"></key>`)
+	assert.Contains(t, buf.String(), `<graph edgedefault="undirected">`)
+}
+
+func TestReadGraphML(t *testing.T) {
+	// keys with generated ids, as written by other tools
+	g, err := ReadGraphML[int, string, int](strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="label" attr.type="string"/>
+  <key id="d1" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="d2" for="node" attr.name="color" attr.type="string"/>
+  <graph id="G" edgedefault="undirected">
+    <node id="0"><data key="d0">a</data><data key="d2">red</data></node>
+    <edge source="0" target="2"><data key="d1"> 4 </data></edge>
+    <node id="2"><data key="d0">c</data></node>
+    <edge source="2" target="3" directed="true"/>
+  </graph>
+</graphml>`), Decoder[int, string]{})
+	assert.NoError(t, err)
+	// directed edge makes whole graph directed
+	assert.Equal(t, Directed, g.Mode())
+	assert.Equal(t, []int{0, 2, 3}, g.Nodes())
+	for k, expected := range map[int]string{0: "a", 2: "c", 3: ""} {
+		v, _ := g.Value(k)
+		assert.Equal(t, expected, v)
+	}
+	assert.Equal(t, []Edge[int, int]{
+		{From: 0, To: 2, Weight: 4},
+		{From: 2, To: 0, Weight: 4},
+		{From: 2, To: 3, Weight: 1},
+	}, allEdges(g))
+
+	for _, invalid := range []string{
+		``,
+		`<graphml><graph><node id="a"/></graph></graphml>`,
+		`<graphml><graph><edge source="0" target="x"/></graph></graphml>`,
+		`<graphml><graph><edge source="0" target="1"><data key="weight">1.5</data></edge></graph></graphml>`,
+		`<graphml><graph>`,
+	} {
+		_, err := ReadGraphML[int, string, int](strings.NewReader(invalid), Decoder[int, string]{})
+		assert.ErrorIs(t, err, ErrInvalidFormat, invalid)
+	}
+}
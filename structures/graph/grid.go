@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"math"
+)
+
+const (
+	// GridStraightCost is weight of horizontal and vertical grid edges
+	GridStraightCost = 10
+	// GridDiagonalCost is weight of diagonal grid edges, 10*sqrt(2) rounded down
+	GridDiagonalCost = 14
+)
+
+// Point on a grid
+type Point struct {
+	X, Y int
+}
+
+// Grid is undirected graph where every node is a cell of rectangular grid
+// connected to its 4 orthogonal or 8 surrounding neighbours
+// Node of a cell is keyed by its Point and holds no value
+type Grid struct {
+	*Graph[Point, struct{}, int]
+	width    int
+	height   int
+	diagonal bool
+}
+
+// NewGrid creates grid graph of given dimensions,
+// diagonal enables moves to diagonal neighbours
+func NewGrid(width, height int, diagonal bool) *Grid {
+	gr := &Grid{
+		Graph:    NewGraph[Point, struct{}, int](Undirected),
+		width:    width,
+		height:   height,
+		diagonal: diagonal,
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			_ = gr.InsertNode(Point{X: x, Y: y}, struct{}{}) // every cell is inserted once
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := Point{X: x, Y: y}
+			gr.neighbours(p, func(np Point, w int) {
+				_ = gr.CreateEdge(p, np, w) // both cells are inside grid
+			})
+		}
+	}
+	return gr
+}
+
+// Block makes cell p impassable by removing all its edges
+func (gr *Grid) Block(p Point) {
+	gr.neighbours(p, func(np Point, _ int) {
+		// edges of blocked neighbour are already removed
+		_ = gr.RemoveEdge(p, np)
+	})
+}
+
+// Manhattan heuristic is admissible only for grid without diagonal moves
+// https://en.wikipedia.org/wiki/Taxicab_geometry
+func (gr *Grid) Manhattan(goal Point) Heuristic[Point, int] {
+	return func(p Point) int {
+		dx, dy := delta(p, goal)
+		return GridStraightCost * (dx + dy)
+	}
+}
+
+// Euclidean heuristic is straight-line distance, it is admissible for any grid
+// Distance is scaled down so diagonal step never exceeds GridDiagonalCost
+func (gr *Grid) Euclidean(goal Point) Heuristic[Point, int] {
+	return func(p Point) int {
+		dx, dy := delta(p, goal)
+		return int(math.Hypot(float64(dx), float64(dy)) * GridDiagonalCost / math.Sqrt2)
+	}
+}
+
+// Octile heuristic is exact cost of unobstructed path on grid with diagonal moves
+func (gr *Grid) Octile(goal Point) Heuristic[Point, int] {
+	return func(p Point) int {
+		dx, dy := delta(p, goal)
+		return GridStraightCost*max(dx, dy) + (GridDiagonalCost-GridStraightCost)*min(dx, dy)
+	}
+}
+
+// delta returns absolute differences of coordinates of two cells
+func delta(a, b Point) (int, int) {
+	return abs(a.X - b.X), abs(a.Y - b.Y)
+}
+
+// neighbours calls fn for every neighbour of cell p with weight of move
+func (gr *Grid) neighbours(p Point, fn func(np Point, w int)) {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			np := Point{X: p.X + dx, Y: p.Y + dy}
+			if (dx == 0 && dy == 0) || np.X < 0 || np.Y < 0 || np.X >= gr.width || np.Y >= gr.height {
+				continue
+			}
+			switch {
+			case dx == 0 || dy == 0:
+				fn(np, GridStraightCost)
+			case gr.diagonal:
+				fn(np, GridDiagonalCost)
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
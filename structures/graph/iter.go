@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"iter"
+
+	"github.com/hasansino/gobasics/structures/queue"
+)
+
+// Visit is kind of depth-first search event
+type Visit uint8
+
+const (
+	// PreVisit happens when node is discovered
+	PreVisit Visit = iota
+	// PostVisit happens when every node reachable from node is finished
+	PostVisit
+)
+
+// String implements fmt.Stringer
+func (v Visit) String() string {
+	if v == PreVisit {
+		return "pre"
+	}
+	return "post"
+}
+
+// BFS iterates over nodes reachable from start in breadth-first order
+// yielding node key and its depth, which is number of edges from start
+// Neighbours are visited in insertion order
+// Yields nothing if start is not in graph
+// https://en.wikipedia.org/wiki/Breadth-first_search
+func (g *Graph[K, V, W]) BFS(start K) iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		i, ok := g.index[start]
+		if !ok {
+			return
+		}
+		for n, depth := range g.bfs(i) {
+			if !yield(g.key(n), depth) {
+				return
+			}
+		}
+	}
+}
+
+// DFS iterates over nodes reachable from start in depth-first order
+// Every node is yielded twice: with PreVisit when it is discovered
+// and with PostVisit when search leaves it
+// Neighbours are visited in insertion order
+// Yields nothing if start is not in graph
+// https://en.wikipedia.org/wiki/Depth-first_search
+func (g *Graph[K, V, W]) DFS(start K) iter.Seq2[K, Visit] {
+	return func(yield func(K, Visit) bool) {
+		i, ok := g.index[start]
+		if !ok {
+			return
+		}
+		for n, visit := range g.dfs(i) {
+			if !yield(g.key(n), visit) {
+				return
+			}
+		}
+	}
+}
+
+// bfs iterates over slots reachable from slot start with their depth
+func (g *Graph[K, V, W]) bfs(start int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		var (
+			q     = queue.NewLLQueue(len(g.nodes))
+			depth = make([]int, len(g.nodes))
+		)
+		for j := range depth {
+			depth[j] = -1
+		}
+
+		// nodes are marked when enqueued, so queue never holds more than len(g.nodes) nodes
+		depth[start] = 0
+		_ = q.Enqueue(start)
+
+		for !q.Empty() {
+			n := q.Dequeue().(int)
+			if !yield(n, depth[n]) {
+				return
+			}
+			for _, a := range g.nodes[n].out {
+				if depth[a.to] == -1 {
+					depth[a.to] = depth[n] + 1
+					_ = q.Enqueue(a.to)
+				}
+			}
+		}
+	}
+}
+
+// dfs iterates over slots reachable from slot start with search events
+func (g *Graph[K, V, W]) dfs(start int) iter.Seq2[int, Visit] {
+	return func(yield func(int, Visit) bool) {
+		// frame of search path, next is position of arc to try next
+		type frame struct {
+			node, next int
+		}
+		var (
+			visited = make([]bool, len(g.nodes))
+			path    = []frame{{node: start}}
+		)
+
+		visited[start] = true
+		if !yield(start, PreVisit) {
+			return
+		}
+		for len(path) > 0 {
+			var (
+				top = &path[len(path)-1]
+				out = g.nodes[top.node].out
+			)
+			for top.next < len(out) && visited[out[top.next].to] {
+				top.next++
+			}
+			if top.next == len(out) {
+				path = path[:len(path)-1]
+				if !yield(top.node, PostVisit) {
+					return
+				}
+				continue
+			}
+			n := out[top.next].to
+			top.next++
+			visited[n] = true
+			if !yield(n, PreVisit) {
+				return
+			}
+			path = append(path, frame{node: n})
+		}
+	}
+}
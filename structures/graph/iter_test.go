@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_BFS_Iterator(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+
+	var nodes, depths []int
+	for n, depth := range g.BFS(0) {
+		nodes = append(nodes, n)
+		depths = append(depths, depth)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 5, 4, 6}, nodes)
+	assert.Equal(t, []int{0, 1, 1, 2, 2, 2, 3}, depths)
+
+	// collect levels
+	var levels [][]int
+	for n, depth := range g.BFS(6) {
+		if depth == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], n)
+	}
+	assert.Equal(t, [][]int{{6}, {3, 5}, {1, 4}, {0, 2}}, levels)
+
+	// stop early
+	nodes = nil
+	for n, depth := range g.BFS(0) {
+		if depth > 1 {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, []int{0, 1, 2}, nodes)
+
+	assert.NoError(t, g.RemoveNode(3))
+	nodes = nil
+	for n := range g.BFS(4) {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, []int{4, 2, 0, 1, 5, 6}, nodes)
+
+	for range g.BFS(3) {
+		t.Error("removed node is traversed")
+	}
+	for range g.BFS(100) {
+		t.Error("out of range node is traversed")
+	}
+}
+
+func TestGraph_DFS_Iterator(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+
+	type event struct {
+		node  int
+		visit Visit
+	}
+	var events []event
+	for n, visit := range g.DFS(0) {
+		events = append(events, event{n, visit})
+	}
+	assert.Equal(t, []event{
+		{0, PreVisit}, {1, PreVisit}, {3, PreVisit}, {4, PreVisit}, {2, PreVisit},
+		{2, PostVisit}, {4, PostVisit}, {6, PreVisit}, {5, PreVisit}, {5, PostVisit},
+		{6, PostVisit}, {3, PostVisit}, {1, PostVisit}, {0, PostVisit},
+	}, events)
+
+	// discovery and finish times
+	var (
+		discovered = make([]int, len(testNodes))
+		finished   = make([]int, len(testNodes))
+		clock      int
+	)
+	for n, visit := range g.DFS(0) {
+		clock++
+		if visit == PreVisit {
+			discovered[n] = clock
+		} else {
+			finished[n] = clock
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 3, 4, 9, 8}, discovered)
+	assert.Equal(t, []int{14, 13, 6, 12, 7, 10, 11}, finished)
+
+	// stop early
+	var nodes []int
+	for n, visit := range g.DFS(0) {
+		if visit == PostVisit {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, []int{0, 1, 3, 4, 2}, nodes)
+
+	for range g.DFS(-1) {
+		t.Error("out of range node is traversed")
+	}
+	assert.Equal(t, "pre", PreVisit.String())
+	assert.Equal(t, "post", PostVisit.String())
+}
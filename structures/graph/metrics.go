@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"errors"
+)
+
+// ErrDisconnected is returned when metric is undefined
+// because some node is unreachable from another one
+var ErrDisconnected = errors.New("graph is disconnected")
+
+// Eccentricity returns maximum distance between given vertex n to any other
+// Distance is sum of edge weights, or number of edges if every edge has weight 1
+// Negative weights are supported, distances are then found by Johnson
+// Returns ErrDisconnected if some node is unreachable from n
+// and *NegativeCycleError if graph has negative cycle
+// https://en.wikipedia.org/wiki/Distance_(graph_theory)
+func (g *Graph[K, V, W]) Eccentricity(n K) (W, error) {
+	i, err := g.slot(n)
+	if err != nil {
+		return 0, err
+	}
+	distances, err := g.distances()
+	if err != nil {
+		return 0, err
+	}
+	return g.eccentricity(distances(i))
+}
+
+// Radius is minimum eccentricity of a graph
+// Returns ErrDisconnected if graph is not strongly connected
+func (g *Graph[K, V, W]) Radius() (W, error) {
+	ecc, err := g.eccentricities()
+	if err != nil || len(ecc) == 0 {
+		return 0, err
+	}
+	radius := ecc[0].value
+	for _, e := range ecc[1:] {
+		radius = min(radius, e.value)
+	}
+	return radius, nil
+}
+
+// Diameter is maximum eccentricity of a graph
+// Returns ErrDisconnected if graph is not strongly connected
+func (g *Graph[K, V, W]) Diameter() (W, error) {
+	ecc, err := g.eccentricities()
+	if err != nil || len(ecc) == 0 {
+		return 0, err
+	}
+	diameter := ecc[0].value
+	for _, e := range ecc[1:] {
+		diameter = max(diameter, e.value)
+	}
+	return diameter, nil
+}
+
+// CentralPoint is node whose eccentricity is equal to radius
+// If there are several such nodes, one inserted first is returned
+// Returns ErrNodeNotFound for empty graph
+func (g *Graph[K, V, W]) CentralPoint() (K, error) {
+	var zero K
+	ecc, err := g.eccentricities()
+	if err != nil {
+		return zero, err
+	}
+	if len(ecc) == 0 {
+		return zero, ErrNodeNotFound
+	}
+	central := ecc[0]
+	for _, e := range ecc[1:] {
+		if e.value < central.value {
+			central = e
+		}
+	}
+	return g.key(central.node), nil
+}
+
+// Circumference returns number of edges in longest simple cycle of graph
+// In directed graph a pair of opposite edges is a cycle of length 2,
+// in undirected graph it is a single edge, so cycles need 3 or more nodes
+// Self-loops are not counted, returns 0 if graph has no cycles
+// Finding longest cycle is NP-hard, exhaustive search is used
+func (g *Graph[K, V, W]) Circumference() int {
+	var (
+		longest  int
+		shortest = 2
+		onPath   = make([]bool, len(g.nodes))
+	)
+	if g.mode == Undirected {
+		shortest = 3
+	}
+	// every cycle is searched only from its first inserted node
+	var walk func(start, n, length int)
+	walk = func(start, n, length int) {
+		onPath[n] = true
+		for _, a := range g.nodes[n].out {
+			if a.to < start {
+				continue
+			}
+			if a.to == start && length >= shortest {
+				longest = max(longest, length)
+			}
+			if !onPath[a.to] {
+				walk(start, a.to, length+1)
+			}
+		}
+		onPath[n] = false
+	}
+	for start := range g.nodes {
+		if g.exists(start) {
+			walk(start, start, 1)
+		}
+	}
+	return longest
+}
+
+// nodeEccentricity is eccentricity value of a node slot
+type nodeEccentricity[W Number] struct {
+	node  int
+	value W
+}
+
+// eccentricities of all nodes in graph
+func (g *Graph[K, V, W]) eccentricities() ([]nodeEccentricity[W], error) {
+	distances, err := g.distances()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]nodeEccentricity[W], 0, len(g.index))
+	for n := range g.nodes {
+		if !g.exists(n) {
+			continue
+		}
+		ecc, err := g.eccentricity(distances(n))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, nodeEccentricity[W]{node: n, value: ecc})
+	}
+	return ret, nil
+}
+
+// distances returns function searching from slot to every node
+// Breadth-first search is used if every edge has weight 1, dijkstra
+// for non-negative weights, otherwise all-pairs distances are found by johnson
+// Returns *NegativeCycleError if graph has negative cycle
+func (g *Graph[K, V, W]) distances() (func(n int) *search[W], error) {
+	switch {
+	case g.unweighted():
+		return g.hopDistances, nil
+	case !g.hasNegativeWeights():
+		return func(n int) *search[W] {
+			s, _ := g.dijkstra(newDijkstraQueue[W](len(g.nodes)), n, g.weight, nil)
+			return s
+		}, nil
+	}
+	searches, err := g.johnson()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) *search[W] {
+		return searches[n]
+	}, nil
+}
+
+// eccentricity of node searched from
+func (g *Graph[K, V, W]) eccentricity(s *search[W]) (W, error) {
+	var ecc W
+	for j := range g.nodes {
+		if !g.exists(j) {
+			continue
+		}
+		if !s.reached[j] {
+			return 0, ErrDisconnected
+		}
+		ecc = max(ecc, s.dist[j])
+	}
+	return ecc, nil
+}
+
+// unweighted tests if every edge of graph has weight 1
+func (g *Graph[K, V, W]) unweighted() bool {
+	for _, n := range g.nodes {
+		if n == nil {
+			continue
+		}
+		for _, a := range n.out {
+			if a.weight != 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hopDistances finds number of edges on shortest path from slot n to every node
+func (g *Graph[K, V, W]) hopDistances(n int) *search[W] {
+	s := newSearch[W](n, len(g.nodes))
+	for j, depth := range g.bfs(n) {
+		s.dist[j], s.reached[j] = W(depth), true
+	}
+	return s
+}
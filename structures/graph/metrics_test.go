@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildGraph creates directed graph with nodes keyed by their indices
+func buildGraph[V any](values []V, edges []struct{ i, j, w int }) *Graph[int, V, int] {
+	g := NewGraph[int, V, int](Directed)
+	for i, v := range values {
+		g.InsertNode(i, v)
+	}
+	for _, v := range edges {
+		g.CreateEdge(v.i, v.j, v.w)
+	}
+	return g
+}
+
+// weightedTestEdges is testEdges with weights
+//
+//	        234
+//	      2/   \2
+//	4 -2- 6    546
+//	9\   9 \   /2
+//	 33     654
+//	  9\   /2
+//	     2
+var weightedTestEdges = []struct{ i, j, w int }{
+	{0, 1, 2}, {1, 0, 2},
+	{1, 5, 2}, {5, 1, 2},
+	{5, 6, 2}, {6, 5, 2},
+	{6, 3, 2}, {3, 6, 2},
+	{3, 4, 2}, {4, 3, 2},
+	{0, 2, 9}, {2, 0, 9},
+	{1, 3, 9}, {3, 1, 9},
+	{2, 4, 9}, {4, 2, 9},
+}
+
+func TestGraph_Eccentricity(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+	for n, expected := range []int{3, 2, 3, 2, 3, 3, 3} {
+		ecc, err := g.Eccentricity(n)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ecc)
+	}
+
+	g = buildGraph(testNodes, weightedTestEdges)
+	for n, expected := range []int{10, 11, 13, 11, 10, 13, 13} {
+		ecc, err := g.Eccentricity(n)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ecc)
+	}
+
+	_, err := g.Eccentricity(-1)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	_, err = g.Eccentricity(len(testNodes))
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_Eccentricity_NegativeWeights(t *testing.T) {
+	// 0 -(-1)-> 1 -2-> 2 -3-> 0
+	g := buildGraph(testNodes[:3], []struct{ i, j, w int }{{0, 1, -1}, {1, 2, 2}, {2, 0, 3}})
+	for n, expected := range []int{1, 5, 3} {
+		ecc, err := g.Eccentricity(n)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ecc)
+	}
+	radius, err := g.Radius()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, radius)
+	diameter, err := g.Diameter()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, diameter)
+	central, err := g.CentralPoint()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, central)
+
+	g.CreateEdge(2, 0, -2)
+	_, err = g.Eccentricity(0)
+	var cycleErr *NegativeCycleError[int]
+	assert.ErrorAs(t, err, &cycleErr)
+	_, err = g.Diameter()
+	assert.ErrorAs(t, err, &cycleErr)
+}
+
+func TestGraph_RadiusDiameter(t *testing.T) {
+	g := buildGraph(testNodes, testEdges)
+	radius, err := g.Radius()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, radius)
+	diameter, err := g.Diameter()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, diameter)
+	central, err := g.CentralPoint()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, central)
+
+	g = buildGraph(testNodes, weightedTestEdges)
+	radius, err = g.Radius()
+	assert.NoError(t, err)
+	assert.Equal(t, 10, radius)
+	diameter, err = g.Diameter()
+	assert.NoError(t, err)
+	assert.Equal(t, 13, diameter)
+	central, err = g.CentralPoint()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, central)
+
+	g = NewGraph[int, int, int](Directed)
+	radius, err = g.Radius()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, radius)
+	_, err = g.CentralPoint()
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_Disconnected(t *testing.T) {
+	// 546 is only reachable, but has no way back
+	g := buildGraph(testNodes, testEdges)
+	g.RemoveEdge(6, 5)
+	g.RemoveEdge(6, 3)
+
+	_, err := g.Eccentricity(6)
+	assert.ErrorIs(t, err, ErrDisconnected)
+	ecc, err := g.Eccentricity(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ecc)
+
+	_, err = g.Radius()
+	assert.ErrorIs(t, err, ErrDisconnected)
+	_, err = g.Diameter()
+	assert.ErrorIs(t, err, ErrDisconnected)
+	_, err = g.CentralPoint()
+	assert.ErrorIs(t, err, ErrDisconnected)
+
+	// removed nodes are not taken into account
+	g.RemoveNode(6)
+	diameter, err := g.Diameter()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, diameter)
+}
+
+func TestGraph_Circumference(t *testing.T) {
+	g := NewGraph[int, int, int](Undirected)
+	for i, v := range testNodes {
+		g.InsertNode(i, v)
+	}
+	for _, e := range testEdges {
+		g.CreateEdge(e.i, e.j, e.w)
+	}
+	assert.Equal(t, 7, g.Circumference())
+
+	// 4 - 6 - 654 - 2 - 33
+	g.RemoveEdge(5, 6)
+	assert.Equal(t, 5, g.Circumference())
+
+	// tree has no cycles
+	g.RemoveEdge(0, 2)
+	assert.Equal(t, 0, g.Circumference())
+
+	// single undirected edge and self-loop are not cycles
+	g = NewGraph[int, int, int](Undirected)
+	g.InsertNode(0, 0)
+	g.InsertNode(1, 0)
+	g.CreateEdge(0, 1, 1)
+	g.CreateEdge(1, 1, 1)
+	assert.Equal(t, 0, g.Circumference())
+
+	// opposite directed edges are cycle of length 2
+	g = buildGraph(testNodes, testEdges)
+	g.RemoveEdge(5, 6)
+	g.RemoveEdge(6, 5)
+	g.RemoveEdge(0, 2)
+	g.RemoveEdge(2, 0)
+	assert.Equal(t, 2, g.Circumference())
+
+	g = buildGraph([]int{0, 0}, []struct{ i, j, w int }{{0, 1, 1}, {1, 1, 1}})
+	assert.Equal(t, 0, g.Circumference())
+	g.CreateEdge(1, 0, 1)
+	assert.Equal(t, 2, g.Circumference())
+
+	// directed cycle 4 -> 6 -> 654 -> 2 -> 33 -> 4
+	g = buildGraph(testNodes, []struct{ i, j, w int }{
+		{0, 1, 1}, {1, 3, 1}, {3, 4, 1}, {4, 2, 1}, {2, 0, 1}, {1, 5, 1}, {5, 6, 1},
+	})
+	assert.Equal(t, 5, g.Circumference())
+}
@@ -0,0 +1,178 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/hasansino/gobasics/structures/heap"
+	"github.com/hasansino/gobasics/structures/unionfind"
+)
+
+// SpanningTree is minimum spanning tree of a graph
+// For disconnected graph it is minimum spanning forest,
+// with one tree for every connected component
+type SpanningTree[K comparable, W Number] struct {
+	// Edges of tree with node inserted earlier first, sorted by insertion order
+	Edges []Edge[K, W]
+	// Weight is sum of edge weights
+	Weight W
+}
+
+// spanningTree builds tree from edges between slots in any order
+func (g *Graph[K, V, W]) spanningTree(edges []edge[W]) *SpanningTree[K, W] {
+	slices.SortFunc(edges, func(a, b edge[W]) int {
+		if a.from != b.from {
+			return cmp.Compare(a.from, b.from)
+		}
+		return cmp.Compare(a.to, b.to)
+	})
+	st := &SpanningTree[K, W]{Edges: g.publicEdges(edges)}
+	for _, e := range edges {
+		st.Weight += e.weight
+	}
+	return st
+}
+
+// undirectedEdges returns edges of graph treated as undirected
+// Pair of opposite edges is merged into one with minimum weight,
+// self-loops are skipped, every edge starts at lower slot
+func (g *Graph[K, V, W]) undirectedEdges() []edge[W] {
+	var edges []edge[W]
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		for _, j := range g.undirectedNeighbours(i) {
+			if j > i {
+				edges = append(edges, edge[W]{from: i, to: j, weight: g.undirectedWeight(i, j)})
+			}
+		}
+	}
+	return edges
+}
+
+// undirectedWeight of edge between slots i and j in any direction
+func (g *Graph[K, V, W]) undirectedWeight(i, j int) W {
+	p, ij := g.arcTo(i, j)
+	q, ji := g.arcTo(j, i)
+	switch {
+	case !ij:
+		return g.nodes[j].out[q].weight
+	case !ji:
+		return g.nodes[i].out[p].weight
+	default:
+		return min(g.nodes[i].out[p].weight, g.nodes[j].out[q].weight)
+	}
+}
+
+// lighterEdge orders edges by weight, ties are broken by slots
+// Total order makes minimum spanning tree unique,
+// so every algorithm returns the same tree
+func lighterEdge[W Number](a, b edge[W]) int {
+	switch {
+	case a.weight != b.weight:
+		return cmp.Compare(a.weight, b.weight)
+	case a.from != b.from:
+		return cmp.Compare(a.from, b.from)
+	default:
+		return cmp.Compare(a.to, b.to)
+	}
+}
+
+// Kruskal finds minimum spanning tree of graph treated as undirected
+// Edges are added from lightest to heaviest, skipping ones which form a cycle
+// https://en.wikipedia.org/wiki/Kruskal%27s_algorithm
+func (g *Graph[K, V, W]) Kruskal() *SpanningTree[K, W] {
+	var (
+		edges = g.undirectedEdges()
+		uf    = unionfind.NewUnionFind(len(g.nodes))
+		tree  []edge[W]
+	)
+	slices.SortFunc(edges, lighterEdge[W])
+	for _, e := range edges {
+		if uf.Union(e.from, e.to) {
+			tree = append(tree, e)
+		}
+	}
+	return g.spanningTree(tree)
+}
+
+// Prim finds minimum spanning tree of graph treated as undirected
+// Tree grows from single node, adding lightest edge leaving it
+// https://en.wikipedia.org/wiki/Prim%27s_algorithm
+func (g *Graph[K, V, W]) Prim() *SpanningTree[K, W] {
+	var (
+		inTree = make([]bool, len(g.nodes))
+		tree   []edge[W]
+		pq     = heap.NewBinaryHeap(lighterEdge[W])
+	)
+	// add node to tree and push edges leaving it
+	add := func(n int) {
+		inTree[n] = true
+		for _, j := range g.undirectedNeighbours(n) {
+			if !inTree[j] {
+				pq.Push(edge[W]{from: min(n, j), to: max(n, j), weight: g.undirectedWeight(n, j)})
+			}
+		}
+	}
+	// every connected component is spanned by its own tree
+	for root := range g.nodes {
+		if !g.exists(root) || inTree[root] {
+			continue
+		}
+		add(root)
+		for pq.Len() > 0 {
+			e, _ := pq.Pop()
+			switch {
+			case !inTree[e.from]:
+				add(e.from)
+			case !inTree[e.to]:
+				add(e.to)
+			default:
+				continue // both ends already in tree
+			}
+			tree = append(tree, e)
+		}
+	}
+	return g.spanningTree(tree)
+}
+
+// Boruvka finds minimum spanning tree of graph treated as undirected
+// In every round each component adds lightest edge leaving it,
+// number of components at least halves each round
+// https://en.wikipedia.org/wiki/Bor%C5%AFvka%27s_algorithm
+func (g *Graph[K, V, W]) Boruvka() *SpanningTree[K, W] {
+	var (
+		edges    = g.undirectedEdges()
+		uf       = unionfind.NewUnionFind(len(g.nodes))
+		cheapest = make([]int, len(g.nodes)) // index of lightest edge leaving component root
+		tree     []edge[W]
+	)
+	for {
+		for j := range cheapest {
+			cheapest[j] = -1
+		}
+		for i, e := range edges {
+			ri, rj := uf.Find(e.from), uf.Find(e.to)
+			if ri == rj {
+				continue
+			}
+			for _, r := range [2]int{ri, rj} {
+				if cheapest[r] == -1 || lighterEdge(e, edges[cheapest[r]]) < 0 {
+					cheapest[r] = i
+				}
+			}
+		}
+		merged := false
+		for _, i := range cheapest {
+			// same edge may be lightest for both components
+			if i != -1 && uf.Union(edges[i].from, edges[i].to) {
+				tree = append(tree, edges[i])
+				merged = true
+			}
+		}
+		if !merged {
+			return g.spanningTree(tree)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_SpanningTree(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+
+	// node 2 is connected by one of 9-weight edges, tie is broken by nodes
+	expected := &SpanningTree[int, int]{
+		Edges: []Edge[int, int]{
+			{From: 0, To: 1, Weight: 2},
+			{From: 0, To: 2, Weight: 9},
+			{From: 1, To: 5, Weight: 2},
+			{From: 3, To: 4, Weight: 2},
+			{From: 3, To: 6, Weight: 2},
+			{From: 5, To: 6, Weight: 2},
+		},
+		Weight: 19,
+	}
+	for name, mst := range map[string]func() *SpanningTree[int, int]{
+		"kruskal": g.Kruskal,
+		"prim":    g.Prim,
+		"boruvka": g.Boruvka,
+	} {
+		assert.Equal(t, expected, mst(), name)
+	}
+}
+
+func TestGraph_SpanningForest(t *testing.T) {
+	// 0 -5- 1 -1- 2   3 <-2- 4   5
+	//  \__-3_____/
+	g := buildGraph(make([]struct{}, 6), []struct{ i, j, w int }{
+		{0, 1, 5},
+		{1, 2, 1},
+		{2, 0, -3},
+		{4, 3, 2},
+		{3, 4, 7}, // lighter direction is used
+		{5, 5, 1}, // self-loops are ignored
+	})
+
+	expected := &SpanningTree[int, int]{
+		Edges: []Edge[int, int]{
+			{From: 0, To: 2, Weight: -3},
+			{From: 1, To: 2, Weight: 1},
+			{From: 3, To: 4, Weight: 2},
+		},
+		Weight: 0,
+	}
+	for name, mst := range map[string]func() *SpanningTree[int, int]{
+		"kruskal": g.Kruskal,
+		"prim":    g.Prim,
+		"boruvka": g.Boruvka,
+	} {
+		assert.Equal(t, expected, mst(), name)
+	}
+
+	g.RemoveNode(2)
+	assert.Equal(t, 7, g.Kruskal().Weight)
+	assert.Equal(t, 7, g.Prim().Weight)
+	assert.Equal(t, 7, g.Boruvka().Weight)
+
+	empty := NewGraph[int, int, int](Directed)
+	assert.Equal(t, &SpanningTree[int, int]{}, empty.Kruskal())
+	assert.Equal(t, &SpanningTree[int, int]{}, empty.Prim())
+	assert.Equal(t, &SpanningTree[int, int]{}, empty.Boruvka())
+}
+
+func TestGraph_SpanningTree_ExtremeWeights(t *testing.T) {
+	// difference of weights overflows int
+	g := buildGraph(make([]struct{}, 3), []struct{ i, j, w int }{
+		{0, 1, math.MaxInt},
+		{1, 2, math.MinInt},
+		{0, 2, 0},
+	})
+	expected := &SpanningTree[int, int]{
+		Edges: []Edge[int, int]{
+			{From: 0, To: 2, Weight: 0},
+			{From: 1, To: 2, Weight: math.MinInt},
+		},
+		Weight: math.MinInt,
+	}
+	for name, mst := range map[string]func() *SpanningTree[int, int]{
+		"kruskal": g.Kruskal,
+		"prim":    g.Prim,
+		"boruvka": g.Boruvka,
+	} {
+		assert.Equal(t, expected, mst(), name)
+	}
+}
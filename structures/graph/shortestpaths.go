@@ -0,0 +1,257 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+)
+
+// NegativeCycleError is returned when shortest paths are undefined
+// because graph has a cycle with negative total weight
+type NegativeCycleError[K comparable] struct {
+	// Cycle lists nodes in order of traversal, first node is not repeated at the end
+	Cycle []K
+}
+
+// Error implements error interface
+func (e *NegativeCycleError[K]) Error() string {
+	return fmt.Sprintf("graph has negative cycle %v", e.Cycle)
+}
+
+// AllPairsShortestPaths is result of all-pairs shortest paths search
+type AllPairsShortestPaths[K comparable, W Number] struct {
+	// From every node shortest paths to every other node
+	From map[K]*ShortestPaths[K, W]
+}
+
+// PathTo returns nodes on shortest path from one node to another
+// Returns nil if target is unreachable
+func (ap *AllPairsShortestPaths[K, W]) PathTo(from, to K) []K {
+	sp, ok := ap.From[from]
+	if !ok {
+		return nil
+	}
+	return sp.PathTo(to)
+}
+
+// edge is edge between node slots
+type edge[W Number] struct {
+	from, to int
+	weight   W
+}
+
+// edgeList returns every arc of graph ordered by source and target slots
+// Undirected edge is listed once, from node inserted earlier
+func (g *Graph[K, V, W]) edgeList() []edge[W] {
+	var edges []edge[W]
+	for i, n := range g.nodes {
+		if n == nil {
+			continue
+		}
+		for _, a := range n.out {
+			if g.mode == Directed || i <= a.to {
+				edges = append(edges, edge[W]{from: i, to: a.to, weight: a.weight})
+			}
+		}
+	}
+	return edges
+}
+
+// arcList returns every arc of graph, in undirected graph edge makes two arcs
+func (g *Graph[K, V, W]) arcList() []edge[W] {
+	var edges []edge[W]
+	for i, n := range g.nodes {
+		if n == nil {
+			continue
+		}
+		for _, a := range n.out {
+			edges = append(edges, edge[W]{from: i, to: a.to, weight: a.weight})
+		}
+	}
+	return edges
+}
+
+// publicEdges converts edges between slots to edges between nodes
+func (g *Graph[K, V, W]) publicEdges(edges []edge[W]) []Edge[K, W] {
+	if edges == nil {
+		return nil
+	}
+	ret := make([]Edge[K, W], len(edges))
+	for j, e := range edges {
+		ret[j] = Edge[K, W]{From: g.key(e.from), To: g.key(e.to), Weight: e.weight}
+	}
+	return ret
+}
+
+// BellmanFord computes shortest paths from node to every other node
+// Unlike Dijkstra it supports negative edge weights, but runs in O(V*E)
+// Returns *NegativeCycleError if negative cycle is reachable from node
+// https://en.wikipedia.org/wiki/Bellman%E2%80%93Ford_algorithm
+func (g *Graph[K, V, W]) BellmanFord(from K) (*ShortestPaths[K, W], error) {
+	i, err := g.slot(from)
+	if err != nil {
+		return nil, err
+	}
+	s := newSearch[W](i, len(g.nodes))
+	if cycle := bellmanFord(g.arcList(), s); cycle != nil {
+		return nil, &NegativeCycleError[K]{Cycle: g.keys(cycle)}
+	}
+	return g.shortestPaths(s), nil
+}
+
+// bellmanFord relaxes every edge until distances stop changing,
+// at most len(s.dist)-1 times
+// Returns slots of negative cycle if distances still change after that
+func bellmanFord[W Number](edges []edge[W], s *search[W]) []int {
+	for n := 0; n < len(s.dist); n++ {
+		updated := -1
+		for _, e := range edges {
+			if !s.reached[e.from] {
+				continue
+			}
+			if d := s.dist[e.from] + e.weight; !s.reached[e.to] || d < s.dist[e.to] {
+				s.dist[e.to], s.reached[e.to] = d, true
+				s.pred[e.to] = e.from
+				updated = e.to
+			}
+		}
+		if updated == -1 {
+			return nil
+		}
+		if n == len(s.dist)-1 {
+			return predecessorsCycle(s.pred, updated)
+		}
+	}
+	return nil
+}
+
+// predecessorsCycle extracts cycle from predecessors of node n,
+// which was updated during last round of bellman-ford relaxation
+func predecessorsCycle(pred []int, n int) []int {
+	// walking back len(pred) steps guarantees we are on the cycle
+	for j := 0; j < len(pred); j++ {
+		n = pred[n]
+	}
+	cycle := []int{n}
+	for j := pred[n]; j != n; j = pred[j] {
+		cycle = append(cycle, j)
+	}
+	slices.Reverse(cycle)
+	return cycle
+}
+
+// FloydWarshall computes shortest paths between every pair of nodes
+// It runs in O(V^3) regardless of number of edges and suits dense graphs
+// Returns *NegativeCycleError if graph has negative cycle
+// https://en.wikipedia.org/wiki/Floyd%E2%80%93Warshall_algorithm
+func (g *Graph[K, V, W]) FloydWarshall() (*AllPairsShortestPaths[K, W], error) {
+	searches := make([]*search[W], len(g.nodes))
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		s := newSearch[W](i, len(g.nodes))
+		for _, a := range g.nodes[i].out {
+			if a.to == i {
+				// only negative self-loop makes path to itself shorter
+				s.dist[i] = min(0, a.weight)
+				continue
+			}
+			s.dist[a.to], s.reached[a.to] = a.weight, true
+			s.pred[a.to] = i
+		}
+		searches[i] = s
+	}
+
+	for k := range searches {
+		if searches[k] == nil {
+			continue
+		}
+		for _, si := range searches {
+			if si == nil || !si.reached[k] {
+				continue
+			}
+			sk := searches[k]
+			for j := range sk.dist {
+				if !sk.reached[j] {
+					continue
+				}
+				if d := si.dist[k] + sk.dist[j]; !si.reached[j] || d < si.dist[j] {
+					si.dist[j], si.reached[j] = d, true
+					si.pred[j] = sk.pred[j]
+				}
+			}
+		}
+	}
+
+	// node on negative cycle has negative distance to itself
+	for i, s := range searches {
+		if s != nil && s.dist[i] < 0 {
+			_, err := g.BellmanFord(g.key(i))
+			return nil, err
+		}
+	}
+
+	return g.allPairsShortestPaths(searches), nil
+}
+
+// Johnson computes shortest paths between every pair of nodes
+// Edges are re-weighted to be non-negative using potentials found
+// by bellman-ford, then dijkstra is run from every node
+// O(V*E log V), which is faster than FloydWarshall for sparse graphs
+// Returns *NegativeCycleError if graph has negative cycle
+// https://en.wikipedia.org/wiki/Johnson%27s_algorithm
+func (g *Graph[K, V, W]) Johnson() (*AllPairsShortestPaths[K, W], error) {
+	searches, err := g.johnson()
+	if err != nil {
+		return nil, err
+	}
+	return g.allPairsShortestPaths(searches), nil
+}
+
+// allPairsShortestPaths converts searches from every slot to result keyed by nodes
+func (g *Graph[K, V, W]) allPairsShortestPaths(searches []*search[W]) *AllPairsShortestPaths[K, W] {
+	ap := &AllPairsShortestPaths[K, W]{From: make(map[K]*ShortestPaths[K, W], len(g.index))}
+	for _, s := range searches {
+		if s != nil {
+			ap.From[g.key(s.source)] = g.shortestPaths(s)
+		}
+	}
+	return ap
+}
+
+// johnson returns search from every slot, nil for removed nodes
+func (g *Graph[K, V, W]) johnson() ([]*search[W], error) {
+	// potentials are distances from virtual node connected
+	// to every node with zero-weight edge
+	h := &search[W]{
+		dist:    make([]W, len(g.nodes)),
+		reached: make([]bool, len(g.nodes)),
+		pred:    make([]int, len(g.nodes)),
+	}
+	for j := range h.pred {
+		h.reached[j], h.pred[j] = true, -1
+	}
+	if cycle := bellmanFord(g.arcList(), h); cycle != nil {
+		return nil, &NegativeCycleError[K]{Cycle: g.keys(cycle)}
+	}
+
+	var (
+		searches = make([]*search[W], len(g.nodes))
+		reweight = func(i int, a arc[W]) W {
+			return a.weight + h.dist[i] - h.dist[a.to]
+		}
+	)
+	for i := range g.nodes {
+		if !g.exists(i) {
+			continue
+		}
+		s, _ := g.dijkstra(newDijkstraQueue[W](len(g.nodes)), i, reweight, nil)
+		for j, ok := range s.reached {
+			if ok {
+				s.dist[j] = s.dist[j] - h.dist[i] + h.dist[j]
+			}
+		}
+		searches[i] = s
+	}
+	return searches, nil
+}
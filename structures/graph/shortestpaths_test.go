@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// negativeTestEdges is directed graph with negative edges but no negative cycles
+//
+//	0 -4-> 1 -(-2)-> 2
+//	|      ^         |
+//	5    (-1)        3
+//	v      |         v
+//	3 -2-> 4 <-1---- 5
+var negativeTestEdges = []struct{ i, j, w int }{
+	{0, 1, 4}, {1, 2, -2}, {0, 3, 5}, {3, 4, 2}, {4, 1, -1}, {2, 5, 3}, {5, 4, 1},
+}
+
+func TestGraph_BellmanFord(t *testing.T) {
+	g := buildGraph(testNodes, weightedTestEdges)
+	for n := range testNodes {
+		expected, err := g.Dijkstra(n)
+		assert.NoError(t, err)
+		sp, err := g.BellmanFord(n)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Distances, sp.Distances)
+		for j := range testNodes {
+			assert.Equal(t, expected.PathTo(j), sp.PathTo(j))
+		}
+	}
+
+	g = buildGraph(testNodes[:6], negativeTestEdges)
+	sp, err := g.BellmanFord(0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 0, 1: 4, 2: 2, 3: 5, 4: 6, 5: 5}, sp.Distances)
+	assert.Equal(t, []int{0, 1, 2, 5, 4}, sp.PathTo(4))
+	assert.Equal(t, []int{0, 3}, sp.PathTo(3))
+
+	_, err = g.Dijkstra(0)
+	assert.ErrorIs(t, err, ErrNegativeWeight)
+	_, err = g.BellmanFord(6)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestGraph_BellmanFord_NegativeCycle(t *testing.T) {
+	g := buildGraph(testNodes[:6], negativeTestEdges)
+	// 1 -> 2 -> 5 -> 4 -> 1 has weight -2 + 3 - 3 - 1 = -3
+	g.CreateEdge(5, 4, -3)
+
+	_, err := g.BellmanFord(0)
+	var cycleErr *NegativeCycleError[int]
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.ElementsMatch(t, []int{1, 2, 5, 4}, cycleErr.Cycle)
+	assertCycle(t, g, cycleErr.Cycle)
+	assert.Contains(t, err.Error(), "negative cycle")
+
+	// cycle is not reachable from 5 once edge 5 -> 4 is removed
+	g.RemoveEdge(5, 4)
+	_, err = g.BellmanFord(5)
+	assert.NoError(t, err)
+
+	// negative self-loop
+	g.CreateEdge(3, 3, -1)
+	_, err = g.BellmanFord(3)
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []int{3}, cycleErr.Cycle)
+}
+
+// assertCycle checks that nodes form a cycle with negative total weight
+func assertCycle[V any](t *testing.T, g *Graph[int, V, int], cycle []int) {
+	var total int
+	for j := range cycle {
+		w, ok := g.Weight(cycle[j], cycle[(j+1)%len(cycle)])
+		if assert.True(t, ok) {
+			total += w
+		}
+	}
+	assert.Less(t, total, 0)
+}
+
+func TestGraph_AllPairsShortestPaths(t *testing.T) {
+	tests := map[string]func(*Graph[int, int, int]) (*AllPairsShortestPaths[int, int], error){
+		"floyd-warshall": (*Graph[int, int, int]).FloydWarshall,
+		"johnson":        (*Graph[int, int, int]).Johnson,
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := buildGraph(testNodes, weightedTestEdges)
+			ap, err := fn(g)
+			assert.NoError(t, err)
+			for n := range testNodes {
+				expected, _ := g.Dijkstra(n)
+				assert.Equal(t, expected.Distances, ap.From[n].Distances)
+				for j := range testNodes {
+					assert.Equal(t, expected.PathTo(j), ap.PathTo(n, j))
+				}
+			}
+			assert.Nil(t, ap.PathTo(-1, 0))
+
+			g = buildGraph(testNodes[:6], negativeTestEdges)
+			ap, err = fn(g)
+			assert.NoError(t, err)
+			for n := range testNodes[:6] {
+				expected, _ := g.BellmanFord(n)
+				assert.Equal(t, expected.Distances, ap.From[n].Distances)
+				for j := range testNodes[:6] {
+					assert.Equal(t, expected.PathTo(j), ap.PathTo(n, j))
+				}
+			}
+
+			// removed node is unreachable
+			g.RemoveNode(3)
+			ap, err = fn(g)
+			assert.NoError(t, err)
+			assert.NotContains(t, ap.From[0].Distances, 3)
+			assert.NotContains(t, ap.From, 3)
+			assert.Nil(t, ap.PathTo(0, 3))
+
+			g.CreateEdge(5, 4, -3)
+			g.CreateEdge(2, 5, 3)
+			g.CreateEdge(4, 1, -1)
+			g.CreateEdge(1, 2, -1)
+			_, err = fn(g)
+			var cycleErr *NegativeCycleError[int]
+			assert.True(t, errors.As(err, &cycleErr))
+			assertCycle(t, g, cycleErr.Cycle)
+		})
+	}
+}
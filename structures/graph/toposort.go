@@ -0,0 +1,187 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/hasansino/gobasics/structures/heap"
+)
+
+// CycleError is returned when graph expected to be acyclic has a cycle
+type CycleError[K comparable] struct {
+	// Cycle lists nodes in order of traversal, first node is not repeated at the end
+	Cycle []K
+}
+
+// Error implements error interface
+func (e *CycleError[K]) Error() string {
+	return fmt.Sprintf("graph has cycle %v", e.Cycle)
+}
+
+// TopologicalSort orders nodes so that for every edge i -> j node i comes before j
+// Of all valid orders the lexicographically smallest by insertion order is returned,
+// so node inserted first goes first as soon as nothing precedes it
+// Returns *CycleError if graph has a cycle
+// https://en.wikipedia.org/wiki/Topological_sorting#Kahn's_algorithm
+func (g *Graph[K, V, W]) TopologicalSort() ([]K, error) {
+	layers, err := g.kahn(false)
+	if err != nil {
+		return nil, err
+	}
+	return g.keys(layers[0]), nil
+}
+
+// LayeredTopologicalSort groups nodes into levels, so that every node depends
+// only on nodes from previous levels and nodes of same level can be processed
+// in parallel; first level holds nodes without incoming edges
+// Nodes of every level are in insertion order
+// Returns *CycleError if graph has a cycle
+func (g *Graph[K, V, W]) LayeredTopologicalSort() ([][]K, error) {
+	layers, err := g.kahn(true)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([][]K, len(layers))
+	for j, layer := range layers {
+		ret[j] = g.keys(layer)
+	}
+	return ret, nil
+}
+
+// kahn repeatedly removes first inserted node among nodes without incoming edges
+// If layered is false all slots are returned in single layer
+func (g *Graph[K, V, W]) kahn(layered bool) ([][]int, error) {
+	var (
+		inDegree = make([]int, len(g.nodes))
+		pq       = heap.NewBinaryHeap(cmp.Compare[int])
+		layers   [][]int
+		sorted   int
+	)
+	for i := range g.nodes {
+		if g.exists(i) {
+			inDegree[i] = len(g.nodes[i].in)
+		}
+	}
+
+	var layer []int
+	for i := range g.nodes {
+		if g.exists(i) && inDegree[i] == 0 {
+			layer = append(layer, i)
+		}
+	}
+	for len(layer) > 0 {
+		if layered || len(layers) == 0 {
+			layers = append(layers, nil)
+		}
+		for _, n := range layer {
+			pq.Push(n)
+		}
+		layer = nil
+		for pq.Len() > 0 {
+			n, _ := pq.Pop()
+			layers[len(layers)-1] = append(layers[len(layers)-1], n)
+			sorted++
+			for _, a := range g.nodes[n].out {
+				if inDegree[a.to]--; inDegree[a.to] == 0 {
+					if layered {
+						layer = append(layer, a.to)
+					} else {
+						pq.Push(a.to)
+					}
+				}
+			}
+		}
+	}
+
+	if sorted < len(g.index) {
+		// nodes left with incoming edges are on a cycle or reachable from one
+		cycle := g.findCycle(func(n int) bool { return inDegree[n] > 0 })
+		return nil, &CycleError[K]{Cycle: g.keys(cycle)}
+	}
+	if layers == nil && !layered {
+		layers = [][]int{{}}
+	}
+	return layers, nil
+}
+
+// TopologicalSortDFS orders nodes so that for every edge i -> j node i comes before j
+// Order is reversed post-order of depth-first search
+// Returns *CycleError if graph has a cycle
+// https://en.wikipedia.org/wiki/Topological_sorting#Depth-first_search
+func (g *Graph[K, V, W]) TopologicalSortDFS() ([]K, error) {
+	var (
+		order = make([]int, 0, len(g.index))
+		cycle []int
+	)
+	g.colorDFS(g.exists, func(n int) {
+		order = append(order, n)
+	}, func(c []int) {
+		cycle = c
+	})
+	if cycle != nil {
+		return nil, &CycleError[K]{Cycle: g.keys(cycle)}
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return g.keys(order), nil
+}
+
+// findCycle returns first cycle found among slots accepted by filter
+func (g *Graph[K, V, W]) findCycle(filter func(n int) bool) []int {
+	var cycle []int
+	g.colorDFS(filter, nil, func(c []int) { cycle = c })
+	return cycle
+}
+
+// colorDFS runs depth-first search from every slot accepted by filter,
+// calling finish for every slot in post-order
+// Search stops as soon as back edge is found, cycle is passed to onCycle
+func (g *Graph[K, V, W]) colorDFS(filter func(n int) bool, finish func(n int), onCycle func(cycle []int)) {
+	const (
+		white = iota // not visited
+		grey         // on current path
+		black        // finished
+	)
+	var (
+		color = make([]int, len(g.nodes))
+		path  []int
+		found bool
+	)
+	var visit func(n int)
+	visit = func(n int) {
+		color[n] = grey
+		path = append(path, n)
+		for _, a := range g.nodes[n].out {
+			if found {
+				break
+			}
+			if !filter(a.to) {
+				continue
+			}
+			switch color[a.to] {
+			case grey:
+				// back edge, cycle is tail of current path starting at a.to
+				for k := len(path) - 1; k >= 0; k-- {
+					if path[k] == a.to {
+						onCycle(append([]int(nil), path[k:]...))
+						break
+					}
+				}
+				found = true
+			case white:
+				visit(a.to)
+			}
+		}
+		path = path[:len(path)-1]
+		color[n] = black
+		if finish != nil && !found {
+			finish(n)
+		}
+	}
+	for i := 0; i < len(g.nodes) && !found; i++ {
+		if g.exists(i) && filter(i) && color[i] == white {
+			visit(i)
+		}
+	}
+}
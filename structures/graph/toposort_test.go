@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dagTestEdges is a dependency graph, edges point from dependency to dependant
+//
+//	0     1
+//	 \   / \
+//	  v v   v
+//	   2    4
+//	    \  /
+//	     vv
+//	     3 -> 5
+var dagTestEdges = []struct{ i, j, w int }{
+	{0, 2, 1},
+	{1, 2, 1},
+	{2, 3, 1},
+	{1, 4, 1},
+	{4, 3, 1},
+	{3, 5, 1},
+}
+
+// assertTopological checks that every edge points forward in order
+func assertTopological(t *testing.T, g *Graph[int, struct{}, int], order []int) {
+	position := make(map[int]int, len(order))
+	for p, n := range order {
+		position[n] = p
+	}
+	assert.Len(t, position, len(order))
+	for _, n := range g.Nodes() {
+		for _, e := range g.Edges(n) {
+			assert.Less(t, position[e.From], position[e.To], "edge %d -> %d", e.From, e.To)
+		}
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := buildGraph(make([]struct{}, 6), dagTestEdges)
+
+	order, err := g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 4, 3, 5}, order)
+	assertTopological(t, g, order)
+
+	order, err = g.TopologicalSortDFS()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 4, 0, 2, 3, 5}, order)
+	assertTopological(t, g, order)
+
+	layers, err := g.LayeredTopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{0, 1}, {2, 4}, {3}, {5}}, layers)
+
+	// removed nodes are not ordered
+	g.RemoveNode(4)
+	order, err = g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 5}, order)
+	order, err = g.TopologicalSortDFS()
+	assert.NoError(t, err)
+	assertTopological(t, g, order)
+	assert.Len(t, order, 5)
+	layers, err = g.LayeredTopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{0, 1}, {2}, {3}, {5}}, layers)
+}
+
+func TestGraph_TopologicalSort_InsertionOrder(t *testing.T) {
+	// FIFO order would put isolated node 2 before 0
+	g := buildGraph(make([]struct{}, 3), []struct{ i, j, w int }{{1, 0, 1}})
+	order, err := g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0, 2}, order)
+
+	layers, err := g.LayeredTopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {0}}, layers)
+
+	// node inserted earlier goes first as soon as its dependencies are sorted
+	g = buildGraph(make([]struct{}, 4), []struct{ i, j, w int }{{3, 0, 1}, {2, 1, 1}, {3, 2, 1}})
+	order, err = g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 0, 2, 1}, order)
+	layers, err = g.LayeredTopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{3}, {0, 2}, {1}}, layers)
+}
+
+func TestGraph_TopologicalSort_Cycle(t *testing.T) {
+	g := buildGraph(make([]struct{}, 6), dagTestEdges)
+	g.CreateEdge(5, 2, 1)
+
+	sorts := map[string]func() ([]int, error){
+		"kahn": g.TopologicalSort,
+		"dfs":  g.TopologicalSortDFS,
+		"layered": func() ([]int, error) {
+			_, err := g.LayeredTopologicalSort()
+			return nil, err
+		},
+	}
+	for name, sort := range sorts {
+		order, err := sort()
+		assert.Nil(t, order, name)
+		var cycleErr *CycleError[int]
+		if assert.True(t, errors.As(err, &cycleErr), name) {
+			assert.Equal(t, []int{2, 3, 5}, cycleErr.Cycle, name)
+		}
+	}
+
+	// self-loop is a cycle too
+	g.RemoveEdge(5, 2)
+	g.CreateEdge(4, 4, 1)
+	for name, sort := range sorts {
+		var cycleErr *CycleError[int]
+		_, err := sort()
+		if assert.True(t, errors.As(err, &cycleErr), name) {
+			assert.Equal(t, []int{4}, cycleErr.Cycle, name)
+		}
+	}
+	assert.EqualError(t, &CycleError[int]{Cycle: []int{4}}, "graph has cycle [4]")
+}
+
+func TestGraph_TopologicalSort_Empty(t *testing.T) {
+	g := NewGraph[int, int, int](Directed)
+	order, err := g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Empty(t, order)
+	order, err = g.TopologicalSortDFS()
+	assert.NoError(t, err)
+	assert.Empty(t, order)
+	layers, err := g.LayeredTopologicalSort()
+	assert.NoError(t, err)
+	assert.Empty(t, layers)
+}
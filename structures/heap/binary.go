@@ -0,0 +1,94 @@
+package heap
+
+// BinaryHeap is generic binary heap stored in slice
+// Ordering is defined by cmp with semantics of cmp.Compare:
+// element for which cmp returns negative value comes out first,
+// so cmp.Compare makes min-heap and reversed comparison makes max-heap
+// Unlike Heap it does not box values and re-balances without recursion
+type BinaryHeap[T any] struct {
+	data []T
+	cmp  func(a, b T) int
+}
+
+// NewBinaryHeap creates empty heap ordered by cmp
+func NewBinaryHeap[T any](cmp func(a, b T) int) *BinaryHeap[T] {
+	return &BinaryHeap[T]{cmp: cmp}
+}
+
+// NewBinaryHeapFrom creates heap of given values in O(n)
+// Values slice is used as heap storage and is reordered in place
+// https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
+func NewBinaryHeapFrom[T any](cmp func(a, b T) int, values []T) *BinaryHeap[T] {
+	h := &BinaryHeap[T]{data: values, cmp: cmp}
+	// leaves are already heaps, sift down every inner node starting from last
+	for pos := len(values)/2 - 1; pos >= 0; pos-- {
+		h.down(pos)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *BinaryHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push value to heap
+func (h *BinaryHeap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.up(len(h.data) - 1)
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *BinaryHeap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Pop removes and returns root value
+// Returns false if heap is empty
+func (h *BinaryHeap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.data) == 0 {
+		return zero, false
+	}
+	v, last := h.data[0], len(h.data)-1
+	h.data[0] = h.data[last]
+	h.data[last] = zero // do not retain popped value
+	h.data = h.data[:last]
+	h.down(0)
+	return v, true
+}
+
+// up moves value at pos towards root until its parent is ordered before it
+func (h *BinaryHeap[T]) up(pos int) {
+	for pos > 0 {
+		parent := (pos - 1) / 2
+		if h.cmp(h.data[pos], h.data[parent]) >= 0 {
+			return
+		}
+		h.data[pos], h.data[parent] = h.data[parent], h.data[pos]
+		pos = parent
+	}
+}
+
+// down moves value at pos towards leaves until it is ordered before its children
+func (h *BinaryHeap[T]) down(pos int) {
+	for {
+		first, left := pos, 2*pos+1
+		if left < len(h.data) && h.cmp(h.data[left], h.data[first]) < 0 {
+			first = left
+		}
+		if right := left + 1; right < len(h.data) && h.cmp(h.data[right], h.data[first]) < 0 {
+			first = right
+		}
+		if first == pos {
+			return
+		}
+		h.data[pos], h.data[first] = h.data[first], h.data[pos]
+		pos = first
+	}
+}
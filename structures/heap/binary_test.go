@@ -0,0 +1,89 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testInts = []int{1, 5, 6, 4, 9, 3, 7}
+
+func TestBinaryHeap(t *testing.T) {
+	h := NewBinaryHeap(cmp.Compare[int])
+	_, ok := h.Peek()
+	assert.False(t, ok)
+	_, ok = h.Pop()
+	assert.False(t, ok)
+
+	for _, v := range testInts {
+		h.Push(v)
+	}
+	assert.Equal(t, []int{1, 4, 3, 5, 9, 6, 7}, h.data)
+	assert.Equal(t, 7, h.Len())
+
+	v, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	var popped []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 6, 7, 9}, popped)
+}
+
+func TestBinaryHeap_MaxHeap(t *testing.T) {
+	h := NewBinaryHeap(func(a, b int) int { return cmp.Compare(b, a) })
+	for _, v := range testInts {
+		h.Push(v)
+	}
+	assert.Equal(t, []int{9, 6, 7, 1, 4, 3, 5}, h.data)
+	v, _ := h.Pop()
+	assert.Equal(t, 9, v)
+	v, _ = h.Pop()
+	assert.Equal(t, 7, v)
+}
+
+func TestNewBinaryHeapFrom(t *testing.T) {
+	values := rand.Perm(1000)
+	h := NewBinaryHeapFrom(cmp.Compare[int], values)
+	assert.Equal(t, 1000, h.Len())
+	for pos := 1; pos < h.Len(); pos++ {
+		assert.LessOrEqual(t, h.data[(pos-1)/2], h.data[pos])
+	}
+	for expected := 0; expected < 1000; expected++ {
+		v, ok := h.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, expected, v)
+	}
+
+	h = NewBinaryHeapFrom[int](cmp.Compare[int], nil)
+	assert.Equal(t, 0, h.Len())
+	h.Push(1)
+	v, _ := h.Peek()
+	assert.Equal(t, 1, v)
+}
+
+func TestBinaryHeap_Sort(t *testing.T) {
+	values := make([]int, 10000)
+	for j := range values {
+		values[j] = rand.IntN(100)
+	}
+	expected := slices.Clone(values)
+	slices.Sort(expected)
+
+	h := NewBinaryHeap(cmp.Compare[int])
+	for _, v := range values {
+		h.Push(v)
+	}
+	sorted := make([]int, 0, len(values))
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		sorted = append(sorted, v)
+	}
+	assert.Equal(t, expected, sorted)
+}
@@ -0,0 +1,155 @@
+package heap
+
+// BinomialHeap is generic heap stored as list of binomial trees
+// of distinct orders, like binary representation of its size
+// Push takes amortized O(1), Peek, Pop and Meld take O(log n)
+// Ordering is defined by cmp same as in BinaryHeap
+// https://en.wikipedia.org/wiki/Binomial_heap
+type BinomialHeap[T any] struct {
+	head *binomialNode[T] // roots in increasing order of degree
+	size int
+	cmp  func(a, b T) int
+}
+
+// binomialNode keeps its children as linked list in decreasing order of degree
+type binomialNode[T any] struct {
+	value   T
+	degree  int
+	child   *binomialNode[T]
+	sibling *binomialNode[T]
+}
+
+// NewBinomialHeap creates empty heap ordered by cmp
+func NewBinomialHeap[T any](cmp func(a, b T) int) *BinomialHeap[T] {
+	return &BinomialHeap[T]{cmp: cmp}
+}
+
+// NewBinomialHeapFrom creates heap of given values in O(n),
+// as every push takes amortized O(1)
+func NewBinomialHeapFrom[T any](cmp func(a, b T) int, values []T) *BinomialHeap[T] {
+	h := NewBinomialHeap(cmp)
+	for _, v := range values {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *BinomialHeap[T]) Len() int {
+	return h.size
+}
+
+// Push value to heap
+func (h *BinomialHeap[T]) Push(v T) {
+	h.head = h.union(h.head, &binomialNode[T]{value: v})
+	h.size++
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *BinomialHeap[T]) Peek() (T, bool) {
+	if h.head == nil {
+		var zero T
+		return zero, false
+	}
+	_, first := h.first()
+	return first.value, true
+}
+
+// Pop removes and returns root value
+// Children of removed root form binomial heap which is melded back
+// Returns false if heap is empty
+func (h *BinomialHeap[T]) Pop() (T, bool) {
+	if h.head == nil {
+		var zero T
+		return zero, false
+	}
+	prev, first := h.first()
+	if prev == nil {
+		h.head = first.sibling
+	} else {
+		prev.sibling = first.sibling
+	}
+
+	// children are in decreasing order of degree, reverse them into root list
+	var children *binomialNode[T]
+	for c := first.child; c != nil; {
+		next := c.sibling
+		c.sibling, children = children, c
+		c = next
+	}
+	h.head = h.union(h.head, children)
+	h.size--
+	return first.value, true
+}
+
+// Meld moves all values of other heap into this one
+// Both heaps have to use the same ordering, other heap is left empty
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) {
+	if other == h {
+		return
+	}
+	h.head = h.union(h.head, other.head)
+	h.size += other.size
+	other.head, other.size = nil, 0
+}
+
+// first returns root ordered first and root preceding it in list
+func (h *BinomialHeap[T]) first() (prev, first *binomialNode[T]) {
+	first = h.head
+	for p, n := h.head, h.head.sibling; n != nil; p, n = n, n.sibling {
+		if h.cmp(n.value, first.value) < 0 {
+			prev, first = p, n
+		}
+	}
+	return prev, first
+}
+
+// union of two root lists, trees of equal degree are linked
+// so that every degree appears at most once
+func (h *BinomialHeap[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	// merge lists by degree
+	var (
+		head *binomialNode[T]
+		tail = &head
+	)
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			*tail, a = a, a.sibling
+		} else {
+			*tail, b = b, b.sibling
+		}
+		tail = &(*tail).sibling
+	}
+	if a != nil {
+		*tail = a
+	} else {
+		*tail = b
+	}
+
+	// link neighbours of equal degree, at most three trees share degree
+	var prev *binomialNode[T]
+	for n := head; n != nil && n.sibling != nil; {
+		next := n.sibling
+		if n.degree != next.degree || (next.sibling != nil && next.sibling.degree == n.degree) {
+			prev, n = n, next
+			continue
+		}
+		if h.cmp(next.value, n.value) < 0 {
+			// next becomes root, n its child
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			n.sibling, next.child = next.child, n
+			next.degree++
+			n = next
+		} else {
+			n.sibling = next.sibling
+			next.sibling, n.child = n.child, next
+			n.degree++
+		}
+	}
+	return head
+}
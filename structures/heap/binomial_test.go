@@ -0,0 +1,48 @@
+package heap
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinomialHeap_Meld(t *testing.T) {
+	a, b := NewBinomialHeap(cmp.Compare[int]), NewBinomialHeap(cmp.Compare[int])
+	for _, v := range testInts {
+		a.Push(v)
+	}
+	for _, v := range testInts[:4] {
+		b.Push(v * 10)
+	}
+
+	// 7 values are stored as trees of orders 0, 1 and 2
+	var degrees []int
+	for n := a.head; n != nil; n = n.sibling {
+		degrees = append(degrees, n.degree)
+	}
+	assert.Equal(t, []int{0, 1, 2}, degrees)
+
+	a.Meld(b)
+	assert.Equal(t, 11, a.Len())
+	assert.Equal(t, 0, b.Len())
+	_, ok := b.Peek()
+	assert.False(t, ok)
+
+	// melding itself or empty heap changes nothing
+	a.Meld(a)
+	a.Meld(b)
+	assert.Equal(t, 11, a.Len())
+
+	var popped []int
+	for a.Len() > 0 {
+		v, _ := a.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 6, 7, 9, 10, 40, 50, 60}, popped)
+
+	b.Push(2)
+	a.Meld(b)
+	v, _ := a.Pop()
+	assert.Equal(t, 2, v)
+}
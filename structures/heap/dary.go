@@ -0,0 +1,91 @@
+package heap
+
+// DaryHeap is generic heap where every node has d children
+// Wider nodes make tree shallower, so Push is faster and Pop does more
+// comparisons per level, but children of node are adjacent in memory
+// which makes them cache-friendly, d of 4 is usually a good choice
+// Ordering is defined by cmp same as in BinaryHeap
+// https://en.wikipedia.org/wiki/D-ary_heap
+type DaryHeap[T any] struct {
+	d    int
+	data []T
+	cmp  func(a, b T) int
+}
+
+// NewDaryHeap creates empty heap with d children per node ordered by cmp
+// D less than 2 is treated as 2, which makes it binary heap
+func NewDaryHeap[T any](d int, cmp func(a, b T) int) *DaryHeap[T] {
+	return &DaryHeap[T]{d: max(d, 2), cmp: cmp}
+}
+
+// NewDaryHeapFrom creates heap of given values in O(n)
+// Values slice is used as heap storage and is reordered in place
+func NewDaryHeapFrom[T any](d int, cmp func(a, b T) int, values []T) *DaryHeap[T] {
+	h := NewDaryHeap(d, cmp)
+	h.data = values
+	// leaves are already heaps, sift down every inner node starting from last
+	for pos := (len(values) - 2) / h.d; pos >= 0; pos-- {
+		h.down(pos)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *DaryHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push value to heap
+func (h *DaryHeap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	for pos := len(h.data) - 1; pos > 0; {
+		parent := (pos - 1) / h.d
+		if h.cmp(h.data[pos], h.data[parent]) >= 0 {
+			return
+		}
+		h.data[pos], h.data[parent] = h.data[parent], h.data[pos]
+		pos = parent
+	}
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Pop removes and returns root value
+// Returns false if heap is empty
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.data) == 0 {
+		return zero, false
+	}
+	v, last := h.data[0], len(h.data)-1
+	h.data[0] = h.data[last]
+	h.data[last] = zero // do not retain popped value
+	h.data = h.data[:last]
+	h.down(0)
+	return v, true
+}
+
+// down moves value at pos towards leaves until it is ordered before its children
+func (h *DaryHeap[T]) down(pos int) {
+	for {
+		first, child := pos, h.d*pos+1
+		for j := child; j < child+h.d && j < len(h.data); j++ {
+			if h.cmp(h.data[j], h.data[first]) < 0 {
+				first = j
+			}
+		}
+		if first == pos {
+			return
+		}
+		h.data[pos], h.data[first] = h.data[first], h.data[pos]
+		pos = first
+	}
+}
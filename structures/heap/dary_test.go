@@ -0,0 +1,31 @@
+package heap
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaryHeap(t *testing.T) {
+	h := NewDaryHeap(3, cmp.Compare[int])
+	for _, v := range testInts {
+		h.Push(v)
+	}
+	// every node is not greater than its 3 children
+	assert.Equal(t, []int{1, 3, 6, 4, 9, 5, 7}, h.data)
+	for pos := 1; pos < h.Len(); pos++ {
+		assert.LessOrEqual(t, h.data[(pos-1)/3], h.data[pos])
+	}
+
+	// d less than 2 makes binary heap
+	for _, d := range []int{-1, 0, 1} {
+		h := NewDaryHeap(d, cmp.Compare[int])
+		assert.Equal(t, 2, h.d)
+		for _, v := range testInts {
+			h.Push(v)
+		}
+		v, _ := h.Pop()
+		assert.Equal(t, 1, v)
+	}
+}
@@ -0,0 +1,254 @@
+package heap
+
+// FibonacciHeap is generic heap with amortized O(1) Push, Meld and DecreaseKey
+// and amortized O(log n) Pop, which suits algorithms doing many decrease-key
+// operations, like Dijkstra on dense graphs
+// Ordering is defined by cmp same as in BinaryHeap
+// https://en.wikipedia.org/wiki/Fibonacci_heap
+type FibonacciHeap[T any] struct {
+	min  *FibonacciNode[T] // root with minimum value, entry to circular root list
+	size int
+	cmp  func(a, b T) int
+}
+
+// FibonacciNode holds value in FibonacciHeap
+// It is returned by Insert and allows to change priority of value
+type FibonacciNode[T any] struct {
+	value T
+	// siblings form circular doubly linked list
+	parent, child, left, right *FibonacciNode[T]
+	degree                     int  // number of children
+	marked                     bool // node lost child since it became child itself
+	removed                    bool
+}
+
+// Value returns value held by node
+func (n *FibonacciNode[T]) Value() T {
+	return n.value
+}
+
+// NewFibonacciHeap creates empty heap ordered by cmp
+func NewFibonacciHeap[T any](cmp func(a, b T) int) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{cmp: cmp}
+}
+
+// NewFibonacciHeapFrom creates heap of given values in O(n),
+// as every push takes O(1)
+func NewFibonacciHeapFrom[T any](cmp func(a, b T) int, values []T) *FibonacciHeap[T] {
+	h := NewFibonacciHeap(cmp)
+	for _, v := range values {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *FibonacciHeap[T]) Len() int {
+	return h.size
+}
+
+// Push value to heap
+func (h *FibonacciHeap[T]) Push(v T) {
+	h.Insert(v)
+}
+
+// Insert value to heap, returned node references it
+func (h *FibonacciHeap[T]) Insert(v T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{value: v}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *FibonacciHeap[T]) Peek() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+	return h.min.value, true
+}
+
+// Pop removes and returns root value
+// Children of removed root become roots and roots of the same degree
+// are linked together until all roots have different degrees
+// Returns false if heap is empty
+func (h *FibonacciHeap[T]) Pop() (T, bool) {
+	z := h.min
+	if z == nil {
+		var zero T
+		return zero, false
+	}
+
+	for z.child != nil {
+		c := z.child
+		h.unlink(c, z)
+		h.addRoot(c)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		z.left.right, z.right.left = z.right, z.left
+		h.consolidate()
+	}
+	z.left, z.right = z, z
+	z.removed = true
+	h.size--
+	return z.value, true
+}
+
+// DecreaseKey replaces value of node with one ordered not later than it
+// Returns false if node is removed or new value is ordered after current one
+// Node must belong to this heap or heap melded into it
+func (h *FibonacciHeap[T]) DecreaseKey(n *FibonacciNode[T], v T) bool {
+	if n == nil || n.removed || h.cmp(v, n.value) > 0 {
+		return false
+	}
+	n.value = v
+	if p := n.parent; p != nil && h.cmp(n.value, p.value) < 0 {
+		h.cut(n, p)
+	}
+	if h.cmp(n.value, h.min.value) < 0 {
+		h.min = n
+	}
+	return true
+}
+
+// Remove value referenced by node from heap
+// Returns false if node is already removed
+// Node must belong to this heap or heap melded into it
+func (h *FibonacciHeap[T]) Remove(n *FibonacciNode[T]) (T, bool) {
+	if n == nil || n.removed {
+		var zero T
+		return zero, false
+	}
+	if p := n.parent; p != nil {
+		h.cut(n, p)
+	}
+	// node is root now, make it minimum regardless of its value and pop it
+	h.min = n
+	return h.Pop()
+}
+
+// Meld moves all values of other heap into this one in O(1)
+// Both heaps have to use the same ordering, other heap is left empty
+// Nodes of other heap stay valid and belong to this heap
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		// splice two circular lists
+		a, b := h.min.right, other.min.left
+		h.min.right, other.min.left = other.min, h.min
+		a.left, b.right = b, a
+		if h.cmp(other.min.value, h.min.value) < 0 {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+	other.min, other.size = nil, 0
+}
+
+// addRoot adds single node to root list
+func (h *FibonacciHeap[T]) addRoot(n *FibonacciNode[T]) {
+	n.parent, n.marked = nil, false
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+	n.left, n.right = h.min, h.min.right
+	h.min.right.left = n
+	h.min.right = n
+	if h.cmp(n.value, h.min.value) < 0 {
+		h.min = n
+	}
+}
+
+// unlink removes node from children of its parent p
+func (h *FibonacciHeap[T]) unlink(n, p *FibonacciNode[T]) {
+	if n.right == n {
+		p.child = nil
+	} else {
+		n.left.right, n.right.left = n.right, n.left
+		if p.child == n {
+			p.child = n.right
+		}
+	}
+	n.left, n.right = n, n
+	p.degree--
+}
+
+// cut moves node to root list, parent which loses second child is cut as well
+func (h *FibonacciHeap[T]) cut(n, p *FibonacciNode[T]) {
+	for {
+		h.unlink(n, p)
+		h.addRoot(n)
+		if p.parent == nil {
+			return
+		}
+		if !p.marked {
+			p.marked = true
+			return
+		}
+		n, p = p, p.parent
+	}
+}
+
+// consolidate links roots of equal degree, min is any root before the call
+func (h *FibonacciHeap[T]) consolidate() {
+	var roots []*FibonacciNode[T]
+	for n := h.min; ; {
+		roots = append(roots, n)
+		if n = n.right; n == h.min {
+			break
+		}
+	}
+
+	var byDegree []*FibonacciNode[T]
+	for _, n := range roots {
+		n.left, n.right = n, n
+		for {
+			for len(byDegree) <= n.degree {
+				byDegree = append(byDegree, nil)
+			}
+			other := byDegree[n.degree]
+			if other == nil {
+				byDegree[n.degree] = n
+				break
+			}
+			byDegree[n.degree] = nil
+			if h.cmp(other.value, n.value) < 0 {
+				n, other = other, n
+			}
+			h.link(other, n)
+		}
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n != nil {
+			h.addRoot(n)
+		}
+	}
+}
+
+// link makes root c a child of root p
+func (h *FibonacciHeap[T]) link(c, p *FibonacciNode[T]) {
+	c.parent, c.marked = p, false
+	if p.child == nil {
+		c.left, c.right = c, c
+		p.child = c
+	} else {
+		c.left, c.right = p.child, p.child.right
+		p.child.right.left = c
+		p.child.right = c
+	}
+	p.degree++
+}
@@ -0,0 +1,129 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFibonacciHeap_Meld(t *testing.T) {
+	a, b := NewFibonacciHeap(cmp.Compare[int]), NewFibonacciHeap(cmp.Compare[int])
+	nodes := make([]*FibonacciNode[int], len(testInts))
+	for i, v := range testInts {
+		a.Push(v)
+		nodes[i] = b.Insert(v * 10)
+	}
+	// consolidate b so it has non-root nodes
+	v, _ := b.Pop()
+	assert.Equal(t, 10, v)
+
+	a.Meld(b)
+	assert.Equal(t, 13, a.Len())
+	assert.Equal(t, 0, b.Len())
+	_, ok := b.Peek()
+	assert.False(t, ok)
+
+	// melding itself or empty heap changes nothing
+	a.Meld(a)
+	a.Meld(b)
+	assert.Equal(t, 13, a.Len())
+
+	// nodes of melded heap belong to a
+	assert.True(t, a.DecreaseKey(nodes[6], 2)) // 70 -> 2
+	v, _ = a.Remove(nodes[2])                  // 60
+	assert.Equal(t, 60, v)
+
+	var popped []int
+	for a.Len() > 0 {
+		v, _ := a.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 9, 30, 40, 50, 90}, popped)
+}
+
+func TestFibonacciHeap_DecreaseKey(t *testing.T) {
+	h := NewFibonacciHeap(cmp.Compare[int])
+	nodes := make([]*FibonacciNode[int], len(testInts))
+	for i, v := range testInts {
+		nodes[i] = h.Insert(v)
+	}
+
+	assert.False(t, h.DecreaseKey(nodes[1], 8)) // 5 -> 8 is increase
+	assert.True(t, h.DecreaseKey(nodes[1], 5))  // equal value is allowed
+	assert.True(t, h.DecreaseKey(nodes[4], 0))  // 9 -> 0
+	assert.Equal(t, 0, nodes[4].Value())
+	v, _ := h.Peek()
+	assert.Equal(t, 0, v)
+
+	v, _ = h.Pop()
+	assert.Equal(t, 0, v)
+	assert.False(t, h.DecreaseKey(nodes[4], -1), "removed node")
+	_, ok := h.Remove(nodes[4])
+	assert.False(t, ok)
+
+	v, ok = h.Remove(nodes[3]) // 4
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	var popped []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 3, 5, 6, 7}, popped)
+}
+
+// TestFibonacciHeap_Model runs random pushes, pops, decreases and removals
+// against sorted slice, deep trees make decreases cut and cascade
+func TestFibonacciHeap_Model(t *testing.T) {
+	var (
+		h     = NewFibonacciHeap(cmp.Compare[int])
+		nodes []*FibonacciNode[int]
+		rnd   = rand.New(rand.NewPCG(1, 2))
+	)
+	model := func() []int {
+		var values []int
+		for _, n := range nodes {
+			values = append(values, n.Value())
+		}
+		slices.Sort(values)
+		return values
+	}
+	forget := func(n *FibonacciNode[int]) {
+		nodes = slices.DeleteFunc(nodes, func(m *FibonacciNode[int]) bool { return m == n })
+	}
+
+	for op := 0; op < 10000; op++ {
+		switch r := rnd.IntN(8); {
+		case r < 4 || len(nodes) == 0:
+			nodes = append(nodes, h.Insert(rnd.IntN(1000)))
+		case r < 6:
+			n := nodes[rnd.IntN(len(nodes))]
+			assert.True(t, h.DecreaseKey(n, n.Value()-rnd.IntN(100)))
+		case r < 7:
+			n := nodes[rnd.IntN(len(nodes))]
+			v, ok := h.Remove(n)
+			assert.True(t, ok)
+			assert.Equal(t, n.Value(), v)
+			forget(n)
+		default:
+			expected := model()[0]
+			v, ok := h.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, expected, v)
+			v, _ = h.Pop()
+			if !assert.Equal(t, expected, v, "operation %d", op) {
+				return
+			}
+			// forget node which was popped
+			forget(nodes[slices.IndexFunc(nodes, func(n *FibonacciNode[int]) bool {
+				return n.removed
+			})])
+		}
+		assert.Equal(t, len(nodes), h.Len())
+	}
+	assert.Equal(t, len(model()), h.Len())
+}
@@ -1,6 +1,19 @@
 //
 // Package heap implements heap data structure.
 //
+// There is several implementations:
+//   * Heap of interface{} values with Less function
+//   * BinaryHeap generic heap ordered by cmp.Compare-style function
+//   * IndexedHeap generic heap with handles allowing to update priority
+//   * MinMaxHeap generic heap with access to both minimum and maximum
+//   * DaryHeap generic heap with d children per node
+//   * PairingHeap generic heap with constant time meld
+//   * BinomialHeap generic heap built of binomial trees
+//   * FibonacciHeap generic heap with constant time meld and decrease-key
+//
+// Generic heaps except IndexedHeap implement PriorityQueue interface,
+// pairing, binomial and fibonacci heaps implement MergeableHeap as well.
+//
 // https://en.wikipedia.org/wiki/Heap_(data_structure)
 // https://afteracademy.com/blog/introduction-to-heaps-in-data-structures
 // https://afteracademy.com/blog/heap-building-and-heap-sort
@@ -0,0 +1,173 @@
+package heap
+
+// Handle references value stored in IndexedHeap
+// It stays valid while value is in heap and allows to change its priority
+type Handle[T any] struct {
+	value T
+	index int // position in heap, -1 once value left heap
+	heap  *IndexedHeap[T]
+}
+
+// Value returns value referenced by handle
+func (hd *Handle[T]) Value() T {
+	return hd.value
+}
+
+// IndexedHeap is binary heap which tracks position of every value,
+// so priority of value can be changed or value removed in O(log n)
+// Ordering is defined by cmp same as in BinaryHeap
+type IndexedHeap[T any] struct {
+	data []*Handle[T]
+	cmp  func(a, b T) int
+}
+
+// NewIndexedHeap creates empty heap ordered by cmp
+func NewIndexedHeap[T any](cmp func(a, b T) int) *IndexedHeap[T] {
+	return &IndexedHeap[T]{cmp: cmp}
+}
+
+// NewIndexedHeapFrom creates heap of given values in O(n)
+// Returned handles reference values in the same order as they were given
+func NewIndexedHeapFrom[T any](cmp func(a, b T) int, values []T) (*IndexedHeap[T], []*Handle[T]) {
+	h := &IndexedHeap[T]{data: make([]*Handle[T], len(values)), cmp: cmp}
+	handles := make([]*Handle[T], len(values))
+	for j, v := range values {
+		handles[j] = &Handle[T]{value: v, index: j, heap: h}
+		h.data[j] = handles[j]
+	}
+	// leaves are already heaps, sift down every inner node starting from last
+	for pos := len(values)/2 - 1; pos >= 0; pos-- {
+		h.down(pos)
+	}
+	return h, handles
+}
+
+// Len returns number of values in heap
+func (h *IndexedHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push value to heap, returned handle references it
+func (h *IndexedHeap[T]) Push(v T) *Handle[T] {
+	hd := &Handle[T]{value: v, index: len(h.data), heap: h}
+	h.data = append(h.data, hd)
+	h.up(hd.index)
+	return hd
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *IndexedHeap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0].value, true
+}
+
+// Pop removes and returns root value
+// Returns false if heap is empty
+func (h *IndexedHeap[T]) Pop() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+// Contains tests if value referenced by handle is in heap
+func (h *IndexedHeap[T]) Contains(hd *Handle[T]) bool {
+	return hd != nil && hd.heap == h && hd.index != -1
+}
+
+// Update replaces value referenced by handle and restores heap order,
+// which makes both decrease-key and increase-key
+// Returns false if value is not in heap
+func (h *IndexedHeap[T]) Update(hd *Handle[T], v T) bool {
+	if !h.Contains(hd) {
+		return false
+	}
+	hd.value = v
+	h.fix(hd.index)
+	return true
+}
+
+// Fix restores heap order after value referenced by handle was changed in place,
+// for example through pointer stored in heap
+// Returns false if value is not in heap
+func (h *IndexedHeap[T]) Fix(hd *Handle[T]) bool {
+	if !h.Contains(hd) {
+		return false
+	}
+	h.fix(hd.index)
+	return true
+}
+
+// Remove value referenced by handle from heap
+// Returns false if value is not in heap
+func (h *IndexedHeap[T]) Remove(hd *Handle[T]) (T, bool) {
+	if !h.Contains(hd) {
+		var zero T
+		return zero, false
+	}
+	return h.remove(hd.index), true
+}
+
+// remove value at pos by replacing it with last one
+func (h *IndexedHeap[T]) remove(pos int) T {
+	hd, last := h.data[pos], len(h.data)-1
+	h.swap(pos, last)
+	h.data[last] = nil
+	h.data = h.data[:last]
+	if pos < last {
+		h.fix(pos)
+	}
+	hd.index = -1
+	return hd.value
+}
+
+// fix moves value at pos up or down, whichever is needed
+func (h *IndexedHeap[T]) fix(pos int) {
+	if !h.down(pos) {
+		h.up(pos)
+	}
+}
+
+// up moves value at pos towards root until its parent is ordered before it
+func (h *IndexedHeap[T]) up(pos int) {
+	for pos > 0 {
+		parent := (pos - 1) / 2
+		if h.cmp(h.data[pos].value, h.data[parent].value) >= 0 {
+			return
+		}
+		h.swap(pos, parent)
+		pos = parent
+	}
+}
+
+// down moves value at pos towards leaves until it is ordered before its children
+// Returns true if value was moved
+func (h *IndexedHeap[T]) down(pos int) bool {
+	start := pos
+	for {
+		first, left := pos, 2*pos+1
+		if left < len(h.data) && h.cmp(h.data[left].value, h.data[first].value) < 0 {
+			first = left
+		}
+		if right := left + 1; right < len(h.data) && h.cmp(h.data[right].value, h.data[first].value) < 0 {
+			first = right
+		}
+		if first == pos {
+			return pos != start
+		}
+		h.swap(pos, first)
+		pos = first
+	}
+}
+
+// swap two positions keeping handle indices in sync
+func (h *IndexedHeap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.data[i].index = i
+	h.data[j].index = j
+}
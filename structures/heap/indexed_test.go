@@ -0,0 +1,149 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	h := NewIndexedHeap(cmp.Compare[int])
+	_, ok := h.Pop()
+	assert.False(t, ok)
+
+	handles := make(map[int]*Handle[int])
+	for _, v := range testInts {
+		handles[v] = h.Push(v)
+	}
+	assert.Equal(t, 7, h.Len())
+	assertIndexed(t, h)
+
+	// decrease-key
+	assert.True(t, h.Update(handles[9], 0))
+	v, _ := h.Peek()
+	assert.Equal(t, 0, v)
+	assert.Equal(t, 0, handles[9].Value())
+	assertIndexed(t, h)
+
+	// increase-key
+	assert.True(t, h.Update(handles[1], 8))
+	assertIndexed(t, h)
+
+	v, ok = h.Remove(handles[5])
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	assert.False(t, h.Contains(handles[5]))
+	_, ok = h.Remove(handles[5])
+	assert.False(t, ok)
+	assert.False(t, h.Update(handles[5], 1))
+	assertIndexed(t, h)
+
+	var popped []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{0, 3, 4, 6, 7, 8}, popped)
+	for _, hd := range handles {
+		assert.False(t, h.Contains(hd))
+	}
+
+	// handle of other heap
+	hd := NewIndexedHeap(cmp.Compare[int]).Push(1)
+	assert.False(t, h.Contains(hd))
+	assert.False(t, h.Fix(hd))
+	assert.False(t, h.Contains(nil))
+}
+
+func TestNewIndexedHeapFrom(t *testing.T) {
+	values := rand.Perm(1000)
+	h, handles := NewIndexedHeapFrom(cmp.Compare[int], values)
+	assert.Equal(t, 1000, h.Len())
+	assertIndexed(t, h)
+	for j, hd := range handles {
+		assert.Equal(t, values[j], hd.Value())
+		assert.True(t, h.Contains(hd))
+	}
+
+	// decrease-key through handle of built heap
+	assert.True(t, h.Update(handles[500], -1))
+	for expected := -1; expected < 1000; expected++ {
+		if expected == values[500] {
+			continue
+		}
+		v, _ := h.Pop()
+		assert.Equal(t, expected, v)
+	}
+	assert.Equal(t, 0, h.Len())
+
+	h, handles = NewIndexedHeapFrom[int](cmp.Compare[int], nil)
+	assert.Equal(t, 0, h.Len())
+	assert.Empty(t, handles)
+}
+
+func TestIndexedHeap_Fix(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	h := NewIndexedHeap(func(a, b *task) int { return cmp.Compare(a.priority, b.priority) })
+	a := h.Push(&task{"a", 1})
+	h.Push(&task{"b", 2})
+	h.Push(&task{"c", 3})
+
+	a.Value().priority = 10
+	assert.True(t, h.Fix(a))
+	v, _ := h.Pop()
+	assert.Equal(t, "b", v.name)
+	v, _ = h.Pop()
+	assert.Equal(t, "c", v.name)
+	v, _ = h.Pop()
+	assert.Equal(t, "a", v.name)
+	assert.False(t, h.Fix(a))
+}
+
+func TestIndexedHeap_Random(t *testing.T) {
+	var (
+		h       = NewIndexedHeap(cmp.Compare[int])
+		handles []*Handle[int]
+	)
+	for j := 0; j < 1000; j++ {
+		handles = append(handles, h.Push(rand.IntN(1000)))
+	}
+	for j := 0; j < 1000; j++ {
+		hd := handles[rand.IntN(len(handles))]
+		if rand.IntN(4) == 0 {
+			h.Remove(hd)
+		} else {
+			h.Update(hd, rand.IntN(1000))
+		}
+	}
+	assertIndexed(t, h)
+
+	var expected []int
+	for _, hd := range handles {
+		if h.Contains(hd) {
+			expected = append(expected, hd.Value())
+		}
+	}
+	slices.Sort(expected)
+	var popped []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, expected, popped)
+}
+
+// assertIndexed checks heap property and positions stored in handles
+func assertIndexed(t *testing.T, h *IndexedHeap[int]) {
+	for pos, hd := range h.data {
+		assert.Equal(t, pos, hd.index)
+		if pos > 0 {
+			assert.LessOrEqual(t, h.data[(pos-1)/2].value, hd.value)
+		}
+	}
+}
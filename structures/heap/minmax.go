@@ -0,0 +1,185 @@
+package heap
+
+import "math/bits"
+
+// MinMaxHeap is generic heap giving access to both ends of ordering
+// Nodes on even levels are smaller than all their descendants
+// and nodes on odd levels are greater than all their descendants,
+// so both minimum and maximum are found in O(1) and removed in O(log n)
+// Ordering is defined by cmp same as in BinaryHeap
+// https://en.wikipedia.org/wiki/Min-max_heap
+type MinMaxHeap[T any] struct {
+	data []T
+	cmp  func(a, b T) int
+}
+
+// NewMinMaxHeap creates empty heap ordered by cmp
+func NewMinMaxHeap[T any](cmp func(a, b T) int) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{cmp: cmp}
+}
+
+// NewMinMaxHeapFrom creates heap of given values in O(n)
+// Values slice is used as heap storage and is reordered in place
+func NewMinMaxHeapFrom[T any](cmp func(a, b T) int, values []T) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{data: values, cmp: cmp}
+	// leaves are already heaps, trickle down every inner node starting from last
+	for pos := len(values)/2 - 1; pos >= 0; pos-- {
+		h.down(pos)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push value to heap
+func (h *MinMaxHeap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.up(len(h.data) - 1)
+}
+
+// Peek returns minimum value, same as PeekMin
+func (h *MinMaxHeap[T]) Peek() (T, bool) {
+	return h.PeekMin()
+}
+
+// Pop removes and returns minimum value, same as PopMin
+func (h *MinMaxHeap[T]) Pop() (T, bool) {
+	return h.PopMin()
+}
+
+// PeekMin returns minimum value without removing it
+// Returns false if heap is empty
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// PeekMax returns maximum value without removing it
+// Returns false if heap is empty
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[h.maxPos()], true
+}
+
+// PopMin removes and returns minimum value
+// Returns false if heap is empty
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+// PopMax removes and returns maximum value
+// Returns false if heap is empty
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(h.maxPos()), true
+}
+
+// maxPos is position of maximum, it is root or one of its children
+func (h *MinMaxHeap[T]) maxPos() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.cmp(h.data[1], h.data[2]) < 0 {
+		return 2
+	}
+	return 1
+}
+
+// remove value at pos by replacing it with last one
+func (h *MinMaxHeap[T]) remove(pos int) T {
+	var (
+		zero T
+		v    = h.data[pos]
+		last = len(h.data) - 1
+	)
+	h.data[pos] = h.data[last]
+	h.data[last] = zero // do not retain removed value
+	h.data = h.data[:last]
+	if pos < last {
+		h.down(pos)
+	}
+	return v
+}
+
+// onMinLevel tests if pos is on even level of tree
+func onMinLevel(pos int) bool {
+	return bits.Len(uint(pos+1))%2 == 1
+}
+
+// before tests if a has to be above b, on min levels smaller values
+// are above greater ones and on max levels it is the opposite
+func (h *MinMaxHeap[T]) before(minLevel bool, a, b T) bool {
+	if minLevel {
+		return h.cmp(a, b) < 0
+	}
+	return h.cmp(a, b) > 0
+}
+
+// up moves new value at pos to its place
+// Value is compared to parent to choose between min and max levels,
+// then it moves up through grandparents on the same kind of levels
+func (h *MinMaxHeap[T]) up(pos int) {
+	if pos == 0 {
+		return
+	}
+	parent := (pos - 1) / 2
+	minLevel := onMinLevel(pos)
+	if h.before(!minLevel, h.data[pos], h.data[parent]) {
+		h.data[pos], h.data[parent] = h.data[parent], h.data[pos]
+		pos, minLevel = parent, !minLevel
+	}
+	for pos > 2 {
+		grandparent := ((pos-1)/2 - 1) / 2
+		if !h.before(minLevel, h.data[pos], h.data[grandparent]) {
+			return
+		}
+		h.data[pos], h.data[grandparent] = h.data[grandparent], h.data[pos]
+		pos = grandparent
+	}
+}
+
+// down moves value at pos towards leaves until it is in order
+// with its children and grandchildren
+func (h *MinMaxHeap[T]) down(pos int) {
+	minLevel := onMinLevel(pos)
+	for {
+		// find smallest (or greatest) of children and grandchildren
+		first, child := -1, 2*pos+1
+		for _, j := range [6]int{child, child + 1, 2*child + 1, 2*child + 2, 2*child + 3, 2*child + 4} {
+			if j < len(h.data) && (first == -1 || h.before(minLevel, h.data[j], h.data[first])) {
+				first = j
+			}
+		}
+		if first == -1 || !h.before(minLevel, h.data[first], h.data[pos]) {
+			return
+		}
+		h.data[pos], h.data[first] = h.data[first], h.data[pos]
+		if first <= child+1 {
+			return // value moved to child, it has no descendants to check
+		}
+		// value moved down two levels can be out of order with its new parent
+		if parent := (first - 1) / 2; h.before(minLevel, h.data[parent], h.data[first]) {
+			h.data[first], h.data[parent] = h.data[parent], h.data[first]
+		}
+		pos = first
+	}
+}
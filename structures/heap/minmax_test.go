@@ -0,0 +1,79 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	h := NewMinMaxHeap(cmp.Compare[int])
+	_, ok := h.PeekMax()
+	assert.False(t, ok)
+	_, ok = h.PopMax()
+	assert.False(t, ok)
+
+	for _, v := range testInts {
+		h.Push(v)
+	}
+	v, _ := h.PeekMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PeekMax()
+	assert.Equal(t, 9, v)
+
+	var popped []int
+	for h.Len() > 0 {
+		v, _ := h.PopMax()
+		popped = append(popped, v)
+		if h.Len() > 0 {
+			v, _ = h.PopMin()
+			popped = append(popped, v)
+		}
+	}
+	assert.Equal(t, []int{9, 1, 7, 3, 6, 4, 5}, popped)
+}
+
+func TestMinMaxHeap_Model(t *testing.T) {
+	var (
+		h     = NewMinMaxHeap(cmp.Compare[int])
+		model []int
+		rnd   = rand.New(rand.NewPCG(3, 4))
+	)
+	for op := 0; op < 10000; op++ {
+		switch r := rnd.IntN(4); {
+		case r < 2 || len(model) == 0:
+			v := rnd.IntN(100)
+			h.Push(v)
+			model = append(model, v)
+			slices.Sort(model)
+		case r == 2:
+			v, _ := h.PopMin()
+			assert.Equal(t, model[0], v, "operation %d", op)
+			model = model[1:]
+		default:
+			v, _ := h.PopMax()
+			assert.Equal(t, model[len(model)-1], v, "operation %d", op)
+			model = model[:len(model)-1]
+		}
+		assertMinMax(t, h)
+	}
+}
+
+// assertMinMax checks that every node is in order with all its descendants
+func assertMinMax(t *testing.T, h *MinMaxHeap[int]) {
+	for pos := 1; pos < h.Len(); pos++ {
+		for ancestor := (pos - 1) / 2; ; ancestor = (ancestor - 1) / 2 {
+			if onMinLevel(ancestor) {
+				assert.LessOrEqual(t, h.data[ancestor], h.data[pos])
+			} else {
+				assert.GreaterOrEqual(t, h.data[ancestor], h.data[pos])
+			}
+			if ancestor == 0 {
+				break
+			}
+		}
+	}
+}
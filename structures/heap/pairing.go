@@ -0,0 +1,116 @@
+package heap
+
+// PairingHeap is generic heap stored as multi-way tree
+// Push, Peek and Meld take O(1), Pop takes amortized O(log n)
+// Ordering is defined by cmp same as in BinaryHeap
+// https://en.wikipedia.org/wiki/Pairing_heap
+type PairingHeap[T any] struct {
+	root *pairingNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+// pairingNode keeps its children as linked list
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T] // first child
+	sibling *pairingNode[T] // next sibling
+}
+
+// NewPairingHeap creates empty heap ordered by cmp
+func NewPairingHeap[T any](cmp func(a, b T) int) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp}
+}
+
+// NewPairingHeapFrom creates heap of given values in O(n),
+// as every push takes O(1)
+func NewPairingHeapFrom[T any](cmp func(a, b T) int, values []T) *PairingHeap[T] {
+	h := NewPairingHeap(cmp)
+	for _, v := range values {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns number of values in heap
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Push value to heap
+func (h *PairingHeap[T]) Push(v T) {
+	h.root = h.link(h.root, &pairingNode[T]{value: v})
+	h.size++
+}
+
+// Peek returns root value without removing it
+// Returns false if heap is empty
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Pop removes and returns root value
+// Children of root are melded in pairs from left to right,
+// then resulting heaps are melded from right to left
+// Returns false if heap is empty
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	v := h.root.value
+
+	var pairs *pairingNode[T] // melded pairs in reverse order, linked by sibling
+	for a := h.root.child; a != nil; {
+		b, next := a.sibling, (*pairingNode[T])(nil)
+		a.sibling = nil
+		if b != nil {
+			next = b.sibling
+			b.sibling = nil
+		}
+		pair := h.link(a, b)
+		pair.sibling, pairs = pairs, pair
+		a = next
+	}
+	var root *pairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+
+	h.root = root
+	h.size--
+	return v, true
+}
+
+// Meld moves all values of other heap into this one in O(1)
+// Both heaps have to use the same ordering, other heap is left empty
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// link makes root ordered later a first child of the other one
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case h.cmp(b.value, a.value) < 0:
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
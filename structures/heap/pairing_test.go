@@ -0,0 +1,40 @@
+package heap
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairingHeap_Meld(t *testing.T) {
+	a, b := NewPairingHeap(cmp.Compare[int]), NewPairingHeap(cmp.Compare[int])
+	for _, v := range testInts {
+		a.Push(v)
+		b.Push(v * 10)
+	}
+
+	a.Meld(b)
+	assert.Equal(t, 14, a.Len())
+	assert.Equal(t, 0, b.Len())
+	_, ok := b.Peek()
+	assert.False(t, ok)
+
+	// melding itself or empty heap changes nothing
+	a.Meld(a)
+	a.Meld(b)
+	assert.Equal(t, 14, a.Len())
+
+	var popped []int
+	for a.Len() > 0 {
+		v, _ := a.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 6, 7, 9, 10, 30, 40, 50, 60, 70, 90}, popped)
+
+	// melded heap stays usable
+	b.Push(2)
+	a.Meld(b)
+	v, _ := a.Pop()
+	assert.Equal(t, 2, v)
+}
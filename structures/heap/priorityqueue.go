@@ -0,0 +1,29 @@
+package heap
+
+// PriorityQueue is common interface of generic heaps
+// Pop and Peek return value ordered first by heap comparison function,
+// they return false if queue is empty
+type PriorityQueue[T any] interface {
+	Len() int
+	Push(v T)
+	Pop() (T, bool)
+	Peek() (T, bool)
+}
+
+// MergeableHeap is priority queue which can absorb heap H of the same kind,
+// leaving it empty
+// https://en.wikipedia.org/wiki/Mergeable_heap
+type MergeableHeap[T any, H any] interface {
+	PriorityQueue[T]
+	Meld(other H)
+}
+
+var (
+	_ PriorityQueue[int] = (*BinaryHeap[int])(nil)
+	_ PriorityQueue[int] = (*MinMaxHeap[int])(nil)
+	_ PriorityQueue[int] = (*DaryHeap[int])(nil)
+
+	_ MergeableHeap[int, *PairingHeap[int]]   = (*PairingHeap[int])(nil)
+	_ MergeableHeap[int, *BinomialHeap[int]]  = (*BinomialHeap[int])(nil)
+	_ MergeableHeap[int, *FibonacciHeap[int]] = (*FibonacciHeap[int])(nil)
+)
@@ -0,0 +1,148 @@
+package heap
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var priorityQueues = map[string]func() PriorityQueue[int]{
+	"binary":    func() PriorityQueue[int] { return NewBinaryHeap(cmp.Compare[int]) },
+	"minmax":    func() PriorityQueue[int] { return NewMinMaxHeap(cmp.Compare[int]) },
+	"2-ary":     func() PriorityQueue[int] { return NewDaryHeap(2, cmp.Compare[int]) },
+	"4-ary":     func() PriorityQueue[int] { return NewDaryHeap(4, cmp.Compare[int]) },
+	"8-ary":     func() PriorityQueue[int] { return NewDaryHeap(8, cmp.Compare[int]) },
+	"pairing":   func() PriorityQueue[int] { return NewPairingHeap(cmp.Compare[int]) },
+	"binomial":  func() PriorityQueue[int] { return NewBinomialHeap(cmp.Compare[int]) },
+	"fibonacci": func() PriorityQueue[int] { return NewFibonacciHeap(cmp.Compare[int]) },
+}
+
+// priorityQueuesFrom build heaps from slice
+var priorityQueuesFrom = map[string]func([]int) PriorityQueue[int]{
+	"binary":    func(v []int) PriorityQueue[int] { return NewBinaryHeapFrom(cmp.Compare[int], v) },
+	"minmax":    func(v []int) PriorityQueue[int] { return NewMinMaxHeapFrom(cmp.Compare[int], v) },
+	"2-ary":     func(v []int) PriorityQueue[int] { return NewDaryHeapFrom(2, cmp.Compare[int], v) },
+	"4-ary":     func(v []int) PriorityQueue[int] { return NewDaryHeapFrom(4, cmp.Compare[int], v) },
+	"8-ary":     func(v []int) PriorityQueue[int] { return NewDaryHeapFrom(8, cmp.Compare[int], v) },
+	"pairing":   func(v []int) PriorityQueue[int] { return NewPairingHeapFrom(cmp.Compare[int], v) },
+	"binomial":  func(v []int) PriorityQueue[int] { return NewBinomialHeapFrom(cmp.Compare[int], v) },
+	"fibonacci": func(v []int) PriorityQueue[int] { return NewFibonacciHeapFrom(cmp.Compare[int], v) },
+}
+
+func TestPriorityQueue_Empty(t *testing.T) {
+	for name, newQueue := range priorityQueues {
+		pq := newQueue()
+		assert.Equal(t, 0, pq.Len(), name)
+		_, ok := pq.Peek()
+		assert.False(t, ok, name)
+		_, ok = pq.Pop()
+		assert.False(t, ok, name)
+
+		pq.Push(1)
+		pq.Pop()
+		_, ok = pq.Pop()
+		assert.False(t, ok, name)
+	}
+}
+
+func TestPriorityQueue_Order(t *testing.T) {
+	for name, newQueue := range priorityQueues {
+		pq := newQueue()
+		for _, v := range testInts {
+			pq.Push(v)
+		}
+		assert.Equal(t, len(testInts), pq.Len(), name)
+		v, ok := pq.Peek()
+		assert.True(t, ok, name)
+		assert.Equal(t, 1, v, name)
+
+		var popped []int
+		for pq.Len() > 0 {
+			v, _ := pq.Pop()
+			popped = append(popped, v)
+		}
+		assert.Equal(t, []int{1, 3, 4, 5, 6, 7, 9}, popped, name)
+	}
+}
+
+func TestPriorityQueue_From(t *testing.T) {
+	for name, newQueue := range priorityQueuesFrom {
+		for _, n := range []int{0, 1, 2, 5, 1000} {
+			pq := newQueue(rand.Perm(n))
+			assert.Equal(t, n, pq.Len(), name)
+			for expected := 0; expected < n; expected++ {
+				v, ok := pq.Pop()
+				if !assert.True(t, ok, name) || !assert.Equal(t, expected, v, "%s: %d values", name, n) {
+					break
+				}
+			}
+			_, ok := pq.Pop()
+			assert.False(t, ok, name)
+
+			pq.Push(1)
+			v, _ := pq.Peek()
+			assert.Equal(t, 1, v, name)
+		}
+	}
+}
+
+// TestPriorityQueue_Model runs random operations against sorted slice
+func TestPriorityQueue_Model(t *testing.T) {
+	for name, newQueue := range priorityQueues {
+		var (
+			pq    = newQueue()
+			model []int
+			rnd   = rand.New(rand.NewPCG(1, 2))
+		)
+		for op := 0; op < 10000; op++ {
+			if rnd.IntN(3) > 0 || len(model) == 0 {
+				v := rnd.IntN(100) // duplicates are common
+				pq.Push(v)
+				model = append(model, v)
+				slices.Sort(model)
+			} else {
+				v, ok := pq.Pop()
+				assert.True(t, ok, name)
+				if !assert.Equal(t, model[0], v, "%s: operation %d", name, op) {
+					break
+				}
+				model = model[1:]
+			}
+			assert.Equal(t, len(model), pq.Len(), name)
+		}
+	}
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16} {
+		values := rand.Perm(n)
+		for name, newQueue := range priorityQueues {
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					pq := newQueue()
+					for _, v := range values {
+						pq.Push(v)
+					}
+					for pq.Len() > 0 {
+						pq.Pop()
+					}
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("interface/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := NewHeap(MinHeap, lessFn)
+				for _, v := range values {
+					h.Insert(v)
+				}
+				for h.Len() > 0 {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+//
+// Package unionfind implements disjoint-set data structure.
+//
+// Elements are integers 0..n-1, every element starts in its own set.
+// Find uses path compression and Union uses union by rank,
+// which makes both operations run in amortized nearly constant time.
+//
+// https://en.wikipedia.org/wiki/Disjoint-set_data_structure
+//
+package unionfind
+
+// UnionFind is forest of sets, each set is a tree identified by its root
+type UnionFind struct {
+	parent []int
+	rank   []uint8 // upper bound of tree height, never exceeds log2(n)
+	count  int
+}
+
+// NewUnionFind creates n disjoint sets with single element each
+func NewUnionFind(n int) *UnionFind {
+	uf := &UnionFind{
+		parent: make([]int, n),
+		rank:   make([]uint8, n),
+		count:  n,
+	}
+	for i := range uf.parent {
+		uf.parent[i] = i
+	}
+	return uf
+}
+
+// Len returns number of elements
+func (uf *UnionFind) Len() int {
+	return len(uf.parent)
+}
+
+// Count returns number of disjoint sets
+func (uf *UnionFind) Count() int {
+	return uf.count
+}
+
+// Find returns representative element of set containing x
+// Every node on the way to root is re-attached directly to it
+func (uf *UnionFind) Find(x int) int {
+	root := x
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for uf.parent[x] != root {
+		uf.parent[x], x = root, uf.parent[x]
+	}
+	return root
+}
+
+// Union merges sets containing x and y
+// Returns false if they are already in the same set
+func (uf *UnionFind) Union(x, y int) bool {
+	rx, ry := uf.Find(x), uf.Find(y)
+	if rx == ry {
+		return false
+	}
+	// attach shorter tree under taller one
+	switch {
+	case uf.rank[rx] < uf.rank[ry]:
+		uf.parent[rx] = ry
+	case uf.rank[rx] > uf.rank[ry]:
+		uf.parent[ry] = rx
+	default:
+		uf.parent[ry] = rx
+		uf.rank[rx]++
+	}
+	uf.count--
+	return true
+}
+
+// Connected tests if x and y are in the same set
+func (uf *UnionFind) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
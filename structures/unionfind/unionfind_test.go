@@ -0,0 +1,55 @@
+package unionfind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := NewUnionFind(10)
+	assert.Equal(t, 10, uf.Len())
+	assert.Equal(t, 10, uf.Count())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, uf.Find(i))
+	}
+
+	assert.True(t, uf.Union(0, 1))
+	assert.True(t, uf.Union(2, 3))
+	assert.True(t, uf.Union(1, 3))
+	assert.False(t, uf.Union(0, 2)) // already connected
+	assert.Equal(t, 7, uf.Count())
+
+	assert.True(t, uf.Connected(0, 3))
+	assert.True(t, uf.Connected(3, 0))
+	assert.False(t, uf.Connected(0, 4))
+	assert.Equal(t, uf.Find(0), uf.Find(2))
+
+	for i := 4; i < 9; i++ {
+		uf.Union(i, i+1)
+	}
+	assert.Equal(t, 2, uf.Count())
+	assert.True(t, uf.Connected(4, 9))
+	assert.False(t, uf.Connected(3, 9))
+}
+
+func TestUnionFind_PathCompression(t *testing.T) {
+	const n = 1 << 10
+	uf := NewUnionFind(n)
+	for i := 1; i < n; i++ {
+		uf.Union(0, i)
+	}
+	assert.Equal(t, 1, uf.Count())
+
+	// union by rank keeps trees shallow
+	for _, r := range uf.rank {
+		assert.LessOrEqual(t, r, uint8(10))
+	}
+
+	// after find every node on the path points to root
+	root := uf.Find(n - 1)
+	for i := 0; i < n; i++ {
+		uf.Find(i)
+		assert.Equal(t, root, uf.parent[i])
+	}
+}